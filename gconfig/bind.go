@@ -0,0 +1,47 @@
+package gconfig
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Bind populates a struct of type T from the configuration subtree under prefix
+// (an empty prefix binds the whole configuration).
+//
+// Each exported field is read from the key named by its `gconfig:"name"` tag, falling back to its
+// `yaml` tag and then its name. Fields tagged `gconfig:"-"` are skipped.
+//   - A `default:"..."` tag supplies a value when the key is missing, converted like a string in the
+//     configuration. Defaults may use templates, e.g. `default:"${{env: REDIS_ADDRESS}}"`.
+//   - The `required` option, e.g. `gconfig:"address,required"`, fails the bind if the key is
+//     missing and there is no default.
+//
+// Keys are matched case-insensitively. Values are otherwise converted exactly as by Get, and like
+// Get, the result is cached.
+func Bind[T any](cfg *Config, prefix string) (T, error) {
+	state := cfg.state.Load()
+	prefix = cfg.fullKey(prefix)
+	k := cacheKey{key: prefix, typ: reflect.TypeFor[T](), bind: true}
+	return computeCached(state, k, func() (T, error) {
+		var result T
+		node := any(state.data)
+		if prefix != "" {
+			var ok bool
+			node, ok = extract(state.data, strings.Split(prefix, "."))
+			if !ok {
+				return result, ErrConfigFailure.Msg("key `%s` not found", prefix)
+			}
+		}
+		c := &converter{bind: true, templates: cfg.templates}
+		err := c.convert(reflect.ValueOf(&result).Elem(), node, prefix)
+		return result, err
+	})
+}
+
+// MustBind is Bind but panics if there are any issues.
+func MustBind[T any](cfg *Config, prefix string) T {
+	v, err := Bind[T](cfg, prefix)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
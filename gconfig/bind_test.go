@@ -0,0 +1,170 @@
+package gconfig_test
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/gconfig"
+	"github.com/drshriveer/gtools/gconfig/internal"
+)
+
+type tlsSettings struct {
+	Cert string `gconfig:"cert"`
+	Key  string `gconfig:"key" default:"/etc/key.pem"`
+}
+
+type replica struct {
+	Name   string `gconfig:"name"`
+	Weight int    `gconfig:"weight" default:"1"`
+}
+
+type serverSettings struct {
+	Name      string                `gconfig:"name,required"`
+	Port      int                   `gconfig:"port"`
+	Timeout   time.Duration         `gconfig:"timeout"`
+	Idle      time.Duration         `gconfig:"idle" default:"30s"`
+	Address   string                `gconfig:"address" default:"${{env:BIND_TEST_ADDRESS|localhost}}"`
+	IP        netip.Addr            `gconfig:"ip"`
+	Dimension internal.DimensionOne `gconfig:"dimension"`
+	Tags      []string              `gconfig:"tags"`
+	Limits    map[string]uint       `gconfig:"limits"`
+	TLS       *tlsSettings          `gconfig:"tls"`
+	Replicas  []replica             `gconfig:"replicas"`
+	Ignored   string                `gconfig:"-"`
+}
+
+func TestBind(t *testing.T) {
+	tests := []struct {
+		description string
+		d1          internal.DimensionOne
+		env         map[string]string
+		expected    serverSettings
+	}{
+		{
+			description: "dimension variation and defaults",
+			d1:          internal.D1a,
+			expected: serverSettings{
+				Name:      "api",
+				Port:      8080,
+				Timeout:   90 * time.Second,
+				Idle:      30 * time.Second,
+				Address:   "localhost",
+				IP:        netip.MustParseAddr("10.0.0.1"),
+				Dimension: internal.D1c,
+				Tags:      []string{"blue", "green"},
+				Limits:    map[string]uint{"read": 10, "write": 5},
+				TLS:       &tlsSettings{Cert: "/etc/cert.pem", Key: "/etc/key.pem"},
+				Replicas:  []replica{{Name: "r1", Weight: 2}, {Name: "r2", Weight: 1}},
+			},
+		},
+		{
+			description: "templated defaults and env overrides",
+			d1:          internal.D1b,
+			env: map[string]string{
+				"BIND_TEST_ADDRESS":         "0.0.0.0",
+				"APP__SERVER__IDLE":         "1m",
+				"APP__SERVER__NAME":         "overridden",
+				"APP__SERVER__LIMITS__READ": "1",
+			},
+			expected: serverSettings{
+				Name:      "overridden",
+				Port:      9090,
+				Timeout:   90 * time.Second,
+				Idle:      time.Minute,
+				Address:   "0.0.0.0",
+				IP:        netip.MustParseAddr("10.0.0.1"),
+				Dimension: internal.D1c,
+				Tags:      []string{"blue", "green"},
+				Limits:    map[string]uint{"read": 1, "write": 5},
+				TLS:       &tlsSettings{Cert: "/etc/cert.pem", Key: "/etc/key.pem"},
+				Replicas:  []replica{{Name: "r1", Weight: 2}, {Name: "r2", Weight: 1}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+			cfg, err := gconfig.NewBuilder().
+				WithDimension("bind-d1", test.d1).
+				WithEnvOverrides("APP", "").
+				FromFile(testFS, "internal/test_bind.yaml")
+			require.NoError(t, err)
+
+			result, err := gconfig.Bind[serverSettings](cfg, "server")
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+
+			// results are cached.
+			assert.Equal(t, result, gconfig.MustBind[serverSettings](cfg, "server"))
+		})
+	}
+}
+
+func TestBind_Errors(t *testing.T) {
+	cfg, err := gconfig.NewBuilder().
+		WithDimension("bind-d1", internal.D1a).
+		FromFile(testFS, "internal/test_bind.yaml")
+	require.NoError(t, err)
+
+	_, err = gconfig.Bind[serverSettings](cfg, "missing")
+	require.ErrorIs(t, err, gconfig.ErrConfigFailure)
+	assert.Contains(t, err.Error(), "key `missing` not found")
+
+	_, err = gconfig.Bind[serverSettings](cfg, "broken")
+	require.ErrorIs(t, err, gconfig.ErrConfigFailure)
+	assert.Contains(t, err.Error(), "key `broken.port`")
+
+	_, err = gconfig.Bind[serverSettings](cfg, "server.tls")
+	require.ErrorIs(t, err, gconfig.ErrConfigFailure)
+	assert.Contains(t, err.Error(), "required key `server.tls.name` is missing")
+
+	assert.Panics(t, func() { gconfig.MustBind[serverSettings](cfg, "broken") })
+}
+
+type sharedBase struct {
+	Region string `yaml:"region"`
+}
+
+type sharedSettings struct {
+	sharedBase
+	Port    int           `yaml:"port"`
+	Enabled bool          `yaml:"enabled"`
+	Timeout time.Duration `yaml:"timeout"`
+	Hosts   []string      `yaml:"hosts"`
+}
+
+func TestBind_MatchesGet(t *testing.T) {
+	cfg, err := gconfig.NewBuilder().FromBytes([]byte(`
+valid:
+  region: eu
+  port: "8080"
+  enabled: "true"
+  timeout: 5s
+  hosts: "[a, b]"
+invalid:
+  port: eighty
+`))
+	require.NoError(t, err)
+
+	expected := sharedSettings{
+		sharedBase: sharedBase{Region: "eu"},
+		Port:       8080,
+		Enabled:    true,
+		Timeout:    5 * time.Second,
+		Hosts:      []string{"a", "b"},
+	}
+	assert.Equal(t, expected, gconfig.MustGet[sharedSettings](cfg, "valid"))
+	assert.Equal(t, expected, gconfig.MustBind[sharedSettings](cfg, "valid"))
+
+	_, err = gconfig.Get[sharedSettings](cfg, "invalid")
+	assert.ErrorContains(t, err, "key `invalid.port`: cannot convert string `eighty` into int")
+	_, err = gconfig.Bind[sharedSettings](cfg, "invalid")
+	assert.ErrorContains(t, err, "key `invalid.port`: cannot convert string `eighty` into int")
+}
@@ -8,18 +8,26 @@ import (
 	"io/fs"
 	"os"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
-	"gopkg.in/yaml.v3"
-
-	"github.com/puzpuzpuz/xsync/v3"
+	"github.com/spf13/pflag"
 
 	"github.com/drshriveer/gtools/genum"
 	"github.com/drshriveer/gtools/gerror"
 	"github.com/drshriveer/gtools/set"
 )
 
-const defaultKey = "default"
+const (
+	defaultKey = "default"
+	// dimensionKeyMarker marks a map key as a dimension key, e.g. `@Prod`.
+	dimensionKeyMarker = "@"
+	// escapedKeyMarker escapes a literal map key starting with dimensionKeyMarker.
+	escapedKeyMarker = dimensionKeyMarker + dimensionKeyMarker
+)
 
 // ErrFailedParsing is returned if there are errors parsing a config file.
 var ErrFailedParsing gerror.Factory = &gerror.GError{
@@ -33,107 +41,215 @@ type dimension struct {
 	// This is also used to determine the
 	defaultVal genum.Enum
 
-	// flagName is the name of the environment flag to parse when parseFlag is true.
+	// flagName is the name of the dimension's flag and environment variable.
 	flagName string
 
-	// parseFlag, if true, will parse the dimension as an environment flag.
-	parseFlag bool
-
-	parsed genum.Enum
-}
-
-func (d *dimension) initFlag() error {
-	d.parsed = d.defaultVal
-
-	if _, ok := d.defaultVal.(encoding.TextMarshaler); !ok {
-		return ErrFailedParsing.Msg(
-			"genum %T does not implement encoding.TextUnmarshaler as required",
-			d.defaultVal)
-	}
-
-	if s, ok := lookupEnv(d.flagName); ok {
-		var err error
-		d.parsed, err = d.defaultVal.ParseGeneric(s)
-		if err != nil {
-			return err
-		}
-	}
-
-	// first look for flags that have already been registered..
-	// if so, this is probably a testing environment, so skip the flag registration.
-	// long term need to decide if we want to disallow this for safety?
-	if !d.parseFlag || flag.Lookup(d.flagName) != nil {
-		return nil
-	}
+	// flagged, if true, registers a flag named flagName in the builder's flag set.
+	flagged bool
 
-	usage := fmt.Sprintf("%s (default=%s): configuration dimension valid options: %s",
-		d.flagName, d.defaultVal, d.defaultVal.StringValues())
+	// resolvers, if set, determine the dimension when the configuration is built.
+	resolvers []DimensionResolver
 
-	flag.Func(d.flagName, usage, func(s string) error {
-		var err error
-		d.parsed, err = d.defaultVal.ParseGeneric(s)
-		return err
-	})
-
-	return nil
+	// parsed is the value of the dimension. A builder's dimensions are never modified once added:
+	// each build resolves copies of them instead (see resolve).
+	parsed genum.Enum
 }
 
 func (d *dimension) get() genum.Enum {
-	if !flag.Parsed() {
-		flag.Parse()
-	}
 	return d.parsed
 }
 
+func (d *dimension) usage() string {
+	return fmt.Sprintf("%s (default=%s): configuration dimension valid options: %s",
+		d.flagName, d.defaultVal, d.defaultVal.StringValues())
+}
+
 // Builder is a configuration builder.
 type Builder struct {
 	// An ordered set of dimensions to switch a configuration on.
 	dimensions []*dimension
+
+	// envOverrides, if set, allows environment variables to override individual keys.
+	envOverrides *envOverrides
+
+	// reloadInterval and onReloadError configure watched configurations.
+	reloadInterval time.Duration
+	onReloadError  func(error)
+
+	// listMerge determines how lists are merged across layers.
+	listMerge ListMergeStrategy
+
+	// expectations are validated against every combination of dimensions at load time.
+	expectations []Expectation
+
+	// templates resolve templated values by name.
+	templates templateResolvers
+
+	// sensitiveKeys are redacted when the configuration is dumped.
+	sensitiveKeys []string
+
+	// explicitDimensionKeys, if true, only treats keys marked with `@` as dimension keys.
+	explicitDimensionKeys bool
+
+	// flags, if set, is the flag set dimension flags are registered with and read from.
+	flags flagSet
+
+	// decoders decode configuration files by extension.
+	decoders decoders
 }
 
 // NewBuilder returns a new builder instance.
 func NewBuilder() *Builder {
-	return &Builder{}
+	return &Builder{templates: defaultTemplateResolvers(), decoders: defaultDecoders()}
 }
 
-// WithDimension adds a new dimension to switch configurations on. By default `parseFlag` will be true when using this method.
+// WithDimension adds a new dimension to switch configurations on.
+// The dimension is read from an environment variable named name and, if WithFlagSet or WithPFlags
+// is used, from a flag named name. Flags are never registered with the global flag.CommandLine
+// or parsed implicitly; use WithFlagSet(flag.CommandLine) to read dimensions from it.
 func (b *Builder) WithDimension(name string, defaultVal genum.Enum) *Builder {
+	if _, ok := defaultVal.(encoding.TextMarshaler); !ok {
+		panic(ErrFailedParsing.Msg(
+			"genum %T does not implement encoding.TextUnmarshaler as required",
+			defaultVal))
+	}
 	d := &dimension{
 		defaultVal: defaultVal,
 		flagName:   name,
-		parseFlag:  true,
+		flagged:    true,
 		parsed:     defaultVal,
+		resolvers:  []DimensionResolver{flagResolver{optional: true}, DimensionFromEnv(name)},
 	}
-	if err := d.initFlag(); err != nil {
-		panic(err)
+	if b.flags != nil {
+		b.flags.register(d)
 	}
 	b.dimensions = append(b.dimensions, d)
 	return b
 }
 
+// WithDimensionValue adds a new dimension to switch configurations on with an explicit value;
+// neither flags nor environment variables are consulted. If explicit is nil defaultVal is used.
+func (b *Builder) WithDimensionValue(name string, defaultVal, explicit genum.Enum) *Builder {
+	if explicit == nil {
+		explicit = defaultVal
+	} else if reflect.TypeOf(explicit) != reflect.TypeOf(defaultVal) {
+		panic(ErrFailedParsing.Msg("dimension %s value %T must be the same type as its default %T",
+			name, explicit, defaultVal))
+	}
+	b.dimensions = append(b.dimensions, &dimension{
+		defaultVal: defaultVal,
+		flagName:   name,
+		parsed:     explicit,
+	})
+	return b
+}
+
+// WithFlagSet registers the flags of dimensions added with WithDimension with flagSet, which is
+// never parsed implicitly: parse it before the configuration is built. Flags are registered for
+// every dimension, whether it was added before or after WithFlagSet, and are read when the
+// configuration is built. If flagSet already has a flag with a dimension's name the dimension is
+// read from that flag.
+func (b *Builder) WithFlagSet(flagSet *flag.FlagSet) *Builder {
+	return b.withFlags(stdFlagSet{flagSet})
+}
+
+// WithPFlags registers the flags of dimensions added with WithDimension with flagSet, a
+// github.com/spf13/pflag flag set (as used by cobra), exactly like WithFlagSet.
+func (b *Builder) WithPFlags(flagSet *pflag.FlagSet) *Builder {
+	return b.withFlags(pFlagSet{flagSet})
+}
+
+func (b *Builder) withFlags(flags flagSet) *Builder {
+	b.flags = flags
+	for _, d := range b.dimensions {
+		if d.flagged {
+			flags.register(d)
+		}
+	}
+	return b
+}
+
+// WithExplicitDimensionKeys makes only keys marked with `@` (e.g. `@Prod`) dimension keys; every other
+// key is a literal map key. Use this when maps are keyed by the same values as a dimension
+// (e.g. regional client addresses when region is also a dimension).
+// Without it, maps whose keys are all values of a dimension are also treated as dimension switches.
+// In either mode a map may not mix marked keys with literal keys, and literal keys that start with `@`
+// must be escaped as `@@`.
+func (b *Builder) WithExplicitDimensionKeys() *Builder {
+	b.explicitDimensionKeys = true
+	return b
+}
+
+// WithEnvOverrides allows individual configuration keys to be overridden by environment variables.
+// A key's variable name is its path joined by the separator, prefixed with the prefix and upper-cased,
+// with `-` characters replaced by `_`. e.g. with prefix `APP` and separator `__` the key
+// `clients.redis.timeout` is overridden by `APP__CLIENTS__REDIS__TIMEOUT`.
+// If separator is empty `__` is used.
+// Overrides take precedence over both the configuration file and dimension reduction.
+// Variables with the prefix that match no key add one, e.g. `APP__NEW__KEY` adds `new.key`.
+// Values are read as raw strings and converted to the type they are fetched as, so
+// `APP__RETRIES=3` may be fetched as an int or a string, and `APP__HOSTS=[a, b]` as a list.
+func (b *Builder) WithEnvOverrides(prefix, separator string) *Builder {
+	if separator == "" {
+		separator = defaultEnvOverrideSeparator
+	}
+	b.envOverrides = &envOverrides{prefix: prefix, separator: separator}
+	return b
+}
+
 // FromFile takes a file system and a path to a configuration file to parse a Config from.
+// The file is decoded by the decoder registered for its extension; see WithDecoder.
 func (b *Builder) FromFile(fileSystem fs.FS, filename string) (*Config, error) {
-	f, err := fileSystem.Open(filename)
+	return b.FromLayers(FileLayer(fileSystem, filename))
+}
+
+// FromBytes takes configuration file bytes and parses a Config object from them.
+// The bytes are decoded by the default decoder, yaml unless replaced with WithDecoder("", ...).
+func (b *Builder) FromBytes(bytes []byte) (*Config, error) {
+	return b.FromLayers(BytesLayer("bytes", bytes))
+}
+
+// FromLayers deep-merges several sources of configuration, in order, into a single Config.
+// Later layers take precedence over earlier ones:
+//   - maps are merged key by key,
+//   - lists are replaced by default (see WithListMerge),
+//   - any other value, including null, replaces the earlier value.
+//
+// Dimensions are reduced after merging, so a layer may add a dimensional variation to a key
+// defined in an earlier layer. Each resolved key remembers the layer(s) that supplied it;
+// see Config.Origins.
+func (b *Builder) FromLayers(layers ...Layer) (*Config, error) {
+	dims, err := b.resolveDimensions()
 	if err != nil {
-		return nil, ErrFailedParsing.Convert(err)
+		return nil, err
 	}
-
-	bytes, err := io.ReadAll(f)
+	state, err := b.build(dims, layers)
 	if err != nil {
-		return nil, ErrFailedParsing.Convert(err)
+		return nil, err
 	}
 
-	return b.FromBytes(bytes)
+	return b.newConfig(dims, state), nil
 }
 
-// FromBytes takes configuration file bytes and parses a Config object from them.
-func (b *Builder) FromBytes(bytes []byte) (*Config, error) {
-	data := make(map[string]any)
-	if err := yaml.Unmarshal(bytes, &data); err != nil {
-		return nil, ErrFailedParsing.Convert(err)
+// build loads, merges, reduces, and resolves layers into the state backing a Config.
+// dims are the dimensions resolved for the configuration; see resolveDimensions.
+func (b *Builder) build(dims []*dimension, layers []Layer) (*configState, error) {
+	if err := b.templates.validate(); err != nil {
+		return nil, err
 	}
 
-	d, err := reduceAny(data, b.dimensions, 0)
+	data, origins, err := mergeLayers(layers, b.decoders, b.listMerge)
+	if err != nil {
+		return nil, err
+	}
+	// reduction happens in place so validation needs its own copy.
+	var unreduced map[string]any
+	if len(b.expectations) > 0 {
+		unreduced = deepCopy(data).(map[string]any)
+	}
+
+	r := b.reducer(dims)
+	d, err := r.reduceAll(data)
 	if err != nil {
 		return nil, err
 	}
@@ -141,68 +257,139 @@ func (b *Builder) FromBytes(bytes []byte) (*Config, error) {
 	if !ok {
 		return nil, ErrFailedParsing.Msg("unexpected non-map result")
 	}
+	// origins mirror the structure of data exactly, so they reduce identically.
+	o, err := r.reduceAll(origins)
+	if err != nil {
+		return nil, err
+	}
+	origins, _ = o.(map[string]any)
 
-	result, err = parseTemplatedElements(result)
+	result, err = parseTemplatedElements(b.templates, result)
 	if err != nil {
 		return nil, err
 	}
+	b.envOverrides.apply(result, origins)
 
-	dims := make(map[reflect.Type]genum.Enum, len(b.dimensions))
-	for _, d := range b.dimensions {
-		dims[reflect.TypeOf(d.defaultVal)] = d.get()
+	if unreduced != nil {
+		if err := b.validate(unreduced); err != nil {
+			return nil, err
+		}
+	}
+
+	return newConfigState(result, origins, layerNames(layers)), nil
+}
+
+// resolveDimensions returns copies of the builder's dimensions set from their resolvers and the flags
+// bound with WithFlagSet or WithPFlags. It is called once per configuration, so reloads never change
+// its dimensions, and never modifies the builder, so configurations may be built concurrently.
+func (b *Builder) resolveDimensions() ([]*dimension, error) {
+	dims := make([]*dimension, len(b.dimensions))
+	for i, d := range b.dimensions {
+		resolved, err := d.resolve(b.flags)
+		if err != nil {
+			return nil, err
+		}
+		dims[i] = resolved
+	}
+	return dims, nil
+}
+
+func (b *Builder) newConfig(dims []*dimension, state *configState) *Config {
+	values := make(map[reflect.Type]genum.Enum, len(dims))
+	for _, d := range dims {
+		values[reflect.TypeOf(d.defaultVal)] = d.get()
 	}
 
 	cfg := &Config{
-		dimensions: dims,
-		cached:     xsync.NewMapOf[string, any](),
-		data:       result,
+		dimensions:    values,
+		templates:     b.templates,
+		sensitiveKeys: b.sensitiveKeys,
+		state:         &atomic.Pointer[configState]{},
+		subscribers:   &subscribers{},
+	}
+	cfg.state.Store(state)
+	return cfg
+}
+
+func readFile(fileSystem fs.FS, filename string) ([]byte, error) {
+	f, err := fileSystem.Open(filename)
+	if err != nil {
+		return nil, ErrFailedParsing.Convert(err)
 	}
-	return cfg, nil
+	defer f.Close()
+
+	bytes, err := io.ReadAll(f)
+	if err != nil {
+		return nil, ErrFailedParsing.Convert(err)
+	}
+	return bytes, nil
+}
+
+// reducer reduces the dimension switches of a configuration to the values of the current dimensions.
+//
+// A map is a dimension switch when its keys are values of a dimension, e.g. `{Prod: x, default: y}`.
+// This is ambiguous for maps that are legitimately keyed by the same values as a dimension
+// (e.g. regional client addresses when region is also a dimension), so switches may be marked
+// explicitly by prefixing their keys with `@`, e.g. `{"@Prod": x, "@default": y}`.
+// With explicitOnly set only marked maps are switches and all other keys are literal map keys.
+// A literal key that starts with `@` is escaped as `@@`.
+type reducer struct {
+	dimensions []*dimension
+	// explicitOnly, if true, treats only keys marked with `@` as dimension keys.
+	explicitOnly bool
+	// onError, if set, is given every key that fails to reduce, which is then reduced to nil
+	// rather than failing the whole reduction.
+	onError func(msg string)
 }
 
-// XXX: I think last time I reduced the keys I traced them down to a bottom value
-// to see if they successfully resolved.
-// . Somehow that doesn't seem right/efficient. Why should I traverse unnecessary options?
-// . But maybe that was a form of validation that the structure I'm tracing is not,
-//   for example, a map type of a dimension type. The best example I can think of for this use case
-//   is regionalization. Sometimes you want a region to be a dimension (setting x is enabled in region y)
-//   but sometimes you want to separate something by a _discoverable_ region (regional client addresses)
-//  . a different version of safety could use special characters to difference a enum from map key.
-//    . the parser/builder step could convert the differenced enums to parsable characters.
-//      . then
+func (b *Builder) reducer(dimensions []*dimension) *reducer {
+	return &reducer{dimensions: dimensions, explicitOnly: b.explicitDimensionKeys}
+}
+
+// reduceAll reduces every dimension switch in a configuration and unescapes literal keys.
+func (r *reducer) reduceAll(in any) (any, error) {
+	out, err := r.reduceAny(in, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	return unescapeKeys(out), nil
+}
 
-func reduceAny(in any, dimensions []*dimension, dIndex int) (any, error) {
+func (r *reducer) reduceAny(in any, paths []string, dIndex int) (any, error) {
 	switch v := in.(type) {
 	case map[string]any:
-		for i := dIndex; i < len(dimensions); i++ {
-			r, err := reduce(v, dimensions, i)
-			if err != nil || !reflect.DeepEqual(r, v) {
-				return r, err
+		if hasMarkedKey(v) {
+			return r.reduceMarked(v, paths, dIndex)
+		}
+		if r.explicitOnly {
+			return r.reduceChildren(v, paths, dIndex)
+		}
+		for i := dIndex; i < len(r.dimensions); i++ {
+			res, err := r.reduce(v, paths, i)
+			if err != nil || !reflect.DeepEqual(res, v) {
+				return res, err
 			}
 		}
 	case []any:
 		for i, el := range v {
 			var err error
-			v[i], err = reduceAny(el, dimensions, dIndex)
+			v[i], err = r.reduceAny(el, appendPath(paths, strconv.Itoa(i)), dIndex)
 			if err != nil {
 				return nil, err
 			}
 		}
-		// I'm returning an ANY here to do the map reduction in place
-		// but this is conflicting with the non-redusable case.
-		// return reduce(v, dimensions, dIndex)
 	}
 	return in, nil
 }
 
-func reduce(in map[string]any, dimensions []*dimension, dIndex int) (any, error) {
+func (r *reducer) reduce(in map[string]any, paths []string, dIndex int) (any, error) {
 	if len(in) == 0 {
 		return in, nil // nothing to reduce.
 	}
-	if dIndex+1 > len(dimensions) {
+	if dIndex+1 > len(r.dimensions) {
 		return in, nil
 	}
-	dim := dimensions[dIndex]
+	dim := r.dimensions[dIndex]
 	// check if this a valid dim to reduce.
 	// if it is, grab the correct one and reduce the rest.
 	keys, hasDefault := keySet(in)
@@ -217,35 +404,166 @@ func reduce(in map[string]any, dimensions []*dimension, dIndex int) (any, error)
 		}
 	}
 	if len(keys) != 0 {
-		for k, v := range in {
-			var err error
-			in[k], err = reduceAny(v, dimensions, dIndex)
-			if err != nil {
-				return nil, err
-			}
-		}
 		// NOT reducable with this dim. need to try next,
-		return in, nil
+		return r.reduceChildren(in, paths, dIndex)
 	}
 	// otherwise this is reducable.
 	// case 1: we have the dim's key. Simply follow it.
 	if v, ok := in[foundDimKey]; ok {
-		return reduceAny(v, dimensions, dIndex+1)
+		return r.reduceAny(v, paths, dIndex+1)
 	}
 	// case 2: we have default
 	if hasDefault {
-		return reduceAny(in[defaultKey], dimensions, dIndex+1)
+		return r.reduceAny(in[defaultKey], paths, dIndex+1)
 	}
 
 	// case 3: we have no default, and no match...
 	// There are sort of two options here.
 	// 1. This is just a completely invalid config
 	// 2. These keys are meant to be part of a map... i.e. intentionally missing properties.
-	// ...going with #1.
+	// ...going with #1. Maps keyed by dimension values should use explicit dimension keys.
 	keys, _ = keySet(in)
-	return nil, ErrFailedParsing.Msg(
+	found := keys.Slice()
+	slices.Sort(found)
+	return r.fail(paths,
 		"broken dim key! %T dimensions identified around keys %s, but no `default` or `%s` value found.",
-		dim.defaultVal, keys.Slice(), dim.get())
+		dim.defaultVal, found, dim.get())
+}
+
+// reduceMarked reduces a map whose keys are marked as dimension keys.
+// Every key must be marked (except `default`) and all must be values of the same dimension.
+func (r *reducer) reduceMarked(in map[string]any, paths []string, dIndex int) (any, error) {
+	marked := make(map[string]string, len(in))
+	literal := make([]string, 0)
+	defaultK := ""
+	for k := range in {
+		switch value, ok := markedKey(k); {
+		case k == defaultKey || (ok && value == defaultKey):
+			if defaultK != "" {
+				return r.fail(paths, "dimension keys define both `%s` and `%s`", defaultK, k)
+			}
+			defaultK = k
+		case ok:
+			marked[k] = value
+		default:
+			literal = append(literal, k)
+		}
+	}
+	if len(literal) > 0 {
+		slices.Sort(literal)
+		return r.fail(paths,
+			"ambiguous keys! dimension keys %s are mixed with map keys %s; "+
+				"mark every dimension key with `@` or escape literal keys starting with `@` as `@@`",
+			sortedKeys(marked), literal)
+	}
+	if len(marked) == 0 {
+		return r.reduceAny(in[defaultK], paths, dIndex)
+	}
+
+	// explicit keys are unambiguous, so any dimension may be switched on regardless of order.
+	for _, dim := range r.dimensions {
+		found, ok := "", true
+		for k, value := range marked {
+			parsed, err := dim.defaultVal.ParseGeneric(value)
+			if err != nil {
+				ok = false
+				break
+			}
+			if dim.get() == parsed {
+				found = k
+			}
+		}
+		if !ok {
+			continue
+		}
+		if found == "" {
+			found = defaultK
+		}
+		if found == "" {
+			return r.fail(paths,
+				"broken dim key! %T dimensions identified around keys %s, but no `default` or `@%s` value found.",
+				dim.defaultVal, sortedKeys(marked), dim.get())
+		}
+		return r.reduceAny(in[found], paths, dIndex)
+	}
+	return r.fail(paths, "dimension keys %s are not all values of a single dimension", sortedKeys(marked))
+}
+
+// reduceChildren reduces the values of a literal map in place.
+func (r *reducer) reduceChildren(in map[string]any, paths []string, dIndex int) (any, error) {
+	for k, v := range in {
+		var err error
+		in[k], err = r.reduceAny(v, appendPath(paths, k), dIndex)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return in, nil
+}
+
+// fail reports a map that cannot be reduced at a key path.
+func (r *reducer) fail(paths []string, format string, args ...any) (any, error) {
+	msg := fmt.Sprintf(format, args...)
+	if len(paths) > 0 {
+		msg = fmt.Sprintf("key `%s`: %s", strings.Join(paths, "."), msg)
+	}
+	if r.onError != nil {
+		r.onError(msg)
+		return nil, nil
+	}
+	return nil, ErrFailedParsing.Msg("%s", msg)
+}
+
+// appendPath returns a copy of paths with an additional element, so sibling paths never share memory.
+func appendPath(paths []string, p string) []string {
+	return append(paths[:len(paths):len(paths)], p)
+}
+
+// markedKey returns the dimension value of a key marked as a dimension key with `@`.
+func markedKey(k string) (string, bool) {
+	if !strings.HasPrefix(k, dimensionKeyMarker) || strings.HasPrefix(k, escapedKeyMarker) {
+		return "", false
+	}
+	return k[len(dimensionKeyMarker):], true
+}
+
+func hasMarkedKey(in map[string]any) bool {
+	for k := range in {
+		if _, ok := markedKey(k); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// unescapeKeys replaces escaped `@@` prefixes of literal map keys with `@`.
+func unescapeKeys(in any) any {
+	switch v := in.(type) {
+	case map[string]any:
+		var escaped []string
+		for k, el := range v {
+			v[k] = unescapeKeys(el)
+			if strings.HasPrefix(k, escapedKeyMarker) {
+				escaped = append(escaped, k)
+			}
+		}
+		for _, k := range escaped {
+			v[k[len(dimensionKeyMarker):]] = v[k]
+			delete(v, k)
+		}
+	case []any:
+		for i, el := range v {
+			v[i] = unescapeKeys(el)
+		}
+	}
+	return in
+}
+
+// sortedKeys returns the keys of a map in order for stable error messages.
+func sortedKeys[V any](in map[string]V) []string {
+	result := keys(in)
+	slices.Sort(result)
+	return result
 }
 
 func keySet(in map[string]any) (set.Set[string], bool) {
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/drshriveer/gtools/genum"
+)
+
+// inferredDimension is a dimension whose valid values are inferred from a configuration file
+// rather than generated by genum, so any configuration can be loaded without its enum types.
+type inferredDimension struct {
+	value  string
+	values *[]string
+}
+
+var _ genum.Enum = inferredDimension{}
+
+func (d inferredDimension) IsValid() bool {
+	_, ok := d.find(d.value)
+	return ok
+}
+
+func (d inferredDimension) StringValues() []string { return *d.values }
+
+func (d inferredDimension) String() string { return d.value }
+
+func (inferredDimension) IsEnum() {}
+
+func (d inferredDimension) ParseGeneric(input any) (genum.Enum, error) {
+	s := fmt.Sprint(input)
+	value, ok := d.find(s)
+	if !ok {
+		return d, fmt.Errorf("%q is not a known value of %v", s, *d.values)
+	}
+	return inferredDimension{value: value, values: d.values}, nil
+}
+
+func (d inferredDimension) MarshalText() ([]byte, error) {
+	return []byte(d.value), nil
+}
+
+func (d inferredDimension) find(s string) (string, bool) {
+	for _, v := range *d.values {
+		if strings.EqualFold(v, s) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// inferValues returns every value of the dimension that value belongs to: the keys of every map
+// that has value as a key, and of every map that has one of those keys, and so on.
+// Returns false if value is not a key of any map.
+func inferValues(data map[string]any, value string) ([]string, bool) {
+	values := []string{value}
+	found := false
+	for changed := true; changed; {
+		changed = false
+		walkMaps(data, func(m map[string]any) {
+			keys := make([]string, 0, len(m))
+			related := false
+			for k := range m {
+				k, ok := dimensionKey(k)
+				if !ok {
+					continue
+				}
+				keys = append(keys, k)
+				related = related || slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, k) })
+			}
+			if !related {
+				return
+			}
+			found = true
+			for _, k := range keys {
+				if !slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, k) }) {
+					values = append(values, k)
+					changed = true
+				}
+			}
+		})
+	}
+	slices.Sort(values)
+	return values, found
+}
+
+// dimensionKey returns the dimension value a map key may represent.
+func dimensionKey(k string) (string, bool) {
+	if k == "default" || k == "@default" || strings.HasPrefix(k, "@@") {
+		return "", false
+	}
+	return strings.TrimPrefix(k, "@"), true
+}
+
+func walkMaps(in any, visit func(m map[string]any)) {
+	switch v := in.(type) {
+	case map[string]any:
+		visit(v)
+		for _, el := range v {
+			walkMaps(el, visit)
+		}
+	case []any:
+		for _, el := range v {
+			walkMaps(el, visit)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+// Command gconfig prints the effective configuration a binary would run with.
+//
+//	gconfig dump [options] <file> --Stage=Prod --Region=EU
+//	gconfig diff [options] <file> --Stage=Beta -- --Stage=Prod
+//
+// Dimensions are passed as `--Name=Value`. As gconfig does not know a binary's dimension
+// types, the valid values of each dimension are inferred from the file (or set with -values).
+// diff compares the configuration of the dimensions before `--` with that of the dimensions
+// after it; dimensions not repeated after `--` keep their value.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/drshriveer/gtools/gconfig"
+)
+
+type options struct {
+	format    string
+	sensitive string
+	explicit  bool
+	values    map[string][]string
+}
+
+type dimensionArg struct {
+	name, value string
+}
+
+var dimensionArgMatcher = regexp.MustCompile(`^--?(\w[\w-]*)=(.*)$`)
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() < 1 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	differs := false
+	switch cmd, args := flag.Arg(0), flag.Args()[1:]; cmd {
+	case "dump":
+		err = dump(os.Stdout, args)
+	case "diff":
+		differs, err = diff(os.Stdout, args)
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gconfig: %s\n", err)
+		os.Exit(2)
+	}
+	if differs {
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprint(flag.CommandLine.Output(), `usage:
+  gconfig dump [options] <file> --Dimension=Value...
+  gconfig diff [options] <file> --Dimension=Value... -- --Dimension=Value...
+
+options:
+`)
+	newFlagSet(&options{}).PrintDefaults()
+}
+
+func newFlagSet(opts *options) *flag.FlagSet {
+	fs := flag.NewFlagSet("gconfig", flag.ContinueOnError)
+	fs.StringVar(&opts.format, "format", "yaml", "output format of dump: yaml or json")
+	fs.StringVar(&opts.sensitive, "sensitive", "",
+		"comma separated keys to redact, e.g. `clients.*.password`")
+	fs.BoolVar(&opts.explicit, "explicit", false, "only treat keys marked with @ as dimension keys")
+	fs.Func("values", "valid values of a dimension, e.g. `Stage=Development,Beta,Prod` (repeatable)",
+		func(s string) error {
+			name, values, ok := strings.Cut(s, "=")
+			if !ok {
+				return fmt.Errorf("expected Name=Value,Value... but got %q", s)
+			}
+			opts.values[name] = strings.Split(values, ",")
+			return nil
+		})
+	return fs
+}
+
+// parseArgs separates options, the file, and dimensions (before and after `--`).
+func parseArgs(args []string) (*options, string, []dimensionArg, []dimensionArg, error) {
+	opts := &options{values: make(map[string][]string)}
+	fs := newFlagSet(opts)
+
+	var rest []string
+	var before, after []dimensionArg
+	dims := &before
+	for _, arg := range args {
+		if arg == "--" {
+			dims = &after
+			continue
+		}
+		m := dimensionArgMatcher.FindStringSubmatch(arg)
+		if m == nil || fs.Lookup(m[1]) != nil {
+			rest = append(rest, arg)
+			continue
+		}
+		*dims = append(*dims, dimensionArg{name: m[1], value: m[2]})
+	}
+	if err := fs.Parse(rest); err != nil {
+		return nil, "", nil, nil, err
+	}
+	if fs.NArg() != 1 {
+		return nil, "", nil, nil, fmt.Errorf("expected exactly one configuration file but got %q", fs.Args())
+	}
+	return opts, fs.Arg(0), before, after, nil
+}
+
+func dump(w io.Writer, args []string) error {
+	opts, filename, dims, _, err := parseArgs(args)
+	if err != nil {
+		return err
+	}
+	format := gconfig.DumpYAML
+	switch opts.format {
+	case "yaml":
+	case "json":
+		format = gconfig.DumpJSON
+	default:
+		return fmt.Errorf("unknown format %q", opts.format)
+	}
+
+	cfg, err := load(opts, filename, dims)
+	if err != nil {
+		return err
+	}
+	return cfg.Dump(w, format)
+}
+
+func diff(w io.Writer, args []string) (bool, error) {
+	opts, filename, before, after, err := parseArgs(args)
+	if err != nil {
+		return false, err
+	}
+	if len(after) == 0 {
+		return false, fmt.Errorf("diff requires dimensions to compare after `--`")
+	}
+	// dimensions not repeated after `--` keep their value.
+	for _, d := range before {
+		if !slices.ContainsFunc(after, func(a dimensionArg) bool { return a.name == d.name }) {
+			after = append(after, d)
+		}
+	}
+
+	left, err := flatten(opts, filename, before)
+	if err != nil {
+		return false, err
+	}
+	right, err := flatten(opts, filename, after)
+	if err != nil {
+		return false, err
+	}
+
+	keys := make([]string, 0, len(left)+len(right))
+	for k := range left {
+		keys = append(keys, k)
+	}
+	for k := range right {
+		if _, ok := left[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	differs := false
+	for _, k := range keys {
+		l, inLeft := left[k]
+		r, inRight := right[k]
+		if inLeft && inRight && l == r {
+			continue
+		}
+		differs = true
+		if inLeft {
+			fmt.Fprintf(w, "- %s: %s\n", k, l)
+		}
+		if inRight {
+			fmt.Fprintf(w, "+ %s: %s\n", k, r)
+		}
+	}
+	return differs, nil
+}
+
+// flatten loads a configuration and returns every leaf value by its key.
+func flatten(opts *options, filename string, dims []dimensionArg) (map[string]string, error) {
+	cfg, err := load(opts, filename, dims)
+	if err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	if err := cfg.Dump(buf, gconfig.DumpJSON); err != nil {
+		return nil, err
+	}
+	var data any
+	if err := json.Unmarshal(buf.Bytes(), &data); err != nil {
+		return nil, err
+	}
+	result := make(map[string]string)
+	flattenInto(result, "", data)
+	return result, nil
+}
+
+func flattenInto(result map[string]string, key string, in any) {
+	if m, ok := in.(map[string]any); ok && len(m) > 0 {
+		for k, v := range m {
+			if key != "" {
+				k = key + "." + k
+			}
+			flattenInto(result, k, v)
+		}
+		return
+	}
+	b, _ := json.Marshal(in)
+	result[key] = string(b)
+}
+
+func load(opts *options, filename string, dims []dimensionArg) (*gconfig.Config, error) {
+	//nolint:gosec // G304 reading the file named on the command line is the point.
+	raw, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := gconfig.DecoderForPath(filename).Decode(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := gconfig.NewBuilder()
+	for _, d := range dims {
+		values, ok := opts.values[d.name]
+		if !ok {
+			if values, ok = inferValues(data, d.value); !ok {
+				return nil, fmt.Errorf("dimension %s: %q is not a key in %s, set its values with -values",
+					d.name, d.value, filename)
+			}
+		}
+		dim, err := inferredDimension{values: &values}.ParseGeneric(d.value)
+		if err != nil {
+			return nil, fmt.Errorf("dimension %s: %w", d.name, err)
+		}
+		builder.WithDimensionValue(d.name, dim, nil)
+	}
+	if opts.sensitive != "" {
+		builder.WithSensitiveKeys(strings.Split(opts.sensitive, ",")...)
+	}
+	if opts.explicit {
+		builder.WithExplicitDimensionKeys()
+	}
+	return builder.FromLayers(gconfig.BytesLayer(filename, raw))
+}
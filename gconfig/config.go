@@ -3,10 +3,11 @@ package gconfig
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
+	"sync/atomic"
 
 	"github.com/puzpuzpuz/xsync/v3"
-	"gopkg.in/yaml.v3"
 
 	"github.com/drshriveer/gtools/genum"
 	"github.com/drshriveer/gtools/gerror"
@@ -22,8 +23,50 @@ var ErrConfigFailure = gerror.FactoryOf(&gerror.GError{
 // Config is the base configuration object that should be supplied to the generic GetX functions.
 type Config struct {
 	dimensions map[reflect.Type]genum.Enum
-	cached     *xsync.MapOf[string, any]
-	data       map[string]any
+	templates  templateResolvers
+
+	// sensitiveKeys are redacted by Dump.
+	sensitiveKeys []string
+
+	// state holds the parsed data and typed cache; it is swapped as a whole
+	// when a watched configuration is reloaded. Views (see Sub) share it with their parent.
+	state *atomic.Pointer[configState]
+
+	// prefix is the key of the subtree a view is scoped to; empty for a full configuration.
+	prefix string
+
+	// watcher is only set when the config was built with Builder.Watch.
+	watcher     *watcher
+	subscribers *subscribers
+}
+
+// configState is an immutable snapshot of configuration data along with
+// the cache of values already converted from it.
+type configState struct {
+	cached *xsync.MapOf[cacheKey, any]
+	data   map[string]any
+
+	// origins mirrors data, but each leaf holds the name of the layer that supplied it.
+	origins map[string]any
+	// layers are the names of all layers in order of precedence (lowest first).
+	layers []string
+}
+
+// cacheKey identifies a cached value: a key (or Bind prefix) converted to a type.
+// Including the type prevents complicated conversions between types sharing a key.
+type cacheKey struct {
+	key  string
+	typ  reflect.Type
+	bind bool
+}
+
+func newConfigState(data, origins map[string]any, layers []string) *configState {
+	return &configState{
+		cached:  xsync.NewMapOf[cacheKey, any](),
+		data:    data,
+		origins: origins,
+		layers:  layers,
+	}
 }
 
 // GetDimension returns the actual value of a dimension.
@@ -36,6 +79,33 @@ func GetDimension[T genum.Enum](cfg *Config) T {
 	return v.(T)
 }
 
+// Origins explains where the value of a key came from by returning the names of the layers
+// (see Builder.FromLayers) that supplied it, lowest precedence first. A scalar value has exactly
+// one origin; maps and lists list every layer that contributed to them.
+// Values supplied by environment overrides are reported as `env:<VARIABLE_NAME>`.
+// Returns false if the key is not found.
+func (c *Config) Origins(key string) ([]string, bool) {
+	state := c.state.Load()
+	v, ok := extract(state.origins, strings.Split(c.fullKey(key), "."))
+	if !ok {
+		return nil, false
+	}
+	found := make(map[string]bool)
+	collectOrigins(v, found)
+
+	result := make([]string, 0, len(found))
+	for _, layer := range state.layers {
+		if found[layer] {
+			result = append(result, layer)
+			delete(found, layer)
+		}
+	}
+	// anything remaining was supplied by the environment.
+	envOrigins := keys(found)
+	sort.Strings(envOrigins)
+	return append(result, envOrigins...), true
+}
+
 // Get fetches a value from the config and returns an error if there is a problem.
 func Get[T any](cfg *Config, key string) (T, error) {
 	return getFromCache[T](cfg, key)
@@ -61,14 +131,29 @@ func GetOrDefault[T any](cfg *Config, key string, defaultV T) T {
 }
 
 func getFromCache[T any](cfg *Config, key string) (T, error) {
+	return getFromState[T](cfg, cfg.state.Load(), cfg.fullKey(key))
+}
+
+func getFromState[T any](cfg *Config, state *configState, key string) (T, error) {
+	k := cacheKey{key: key, typ: reflect.TypeFor[T]()}
+	return computeCached(state, k, func() (T, error) {
+		return extractAndConvert[T](state.data, key)
+	})
+}
+
+// computeCached returns the value cached under k, computing and caching it if needed.
+func computeCached[T any](state *configState, k cacheKey, compute func() (T, error)) (T, error) {
+	if v, ok := state.cached.Load(k); ok {
+		return v.(T), nil
+	}
+
 	var err error
 	var r T
-	k := key + fmt.Sprintf("%T", r) // add type to key to prevent complicated conversions.
-	v, _ := cfg.cached.Compute(k, func(oldValue any, loaded bool) (newValue any, shouldDelete bool) {
+	v, _ := state.cached.Compute(k, func(oldValue any, loaded bool) (newValue any, shouldDelete bool) {
 		if loaded {
 			return oldValue, false
 		}
-		oldValue, err = extractAndConvert[T](cfg.data, key)
+		oldValue, err = compute()
 		if err != nil {
 			return oldValue, true
 		}
@@ -76,32 +161,21 @@ func getFromCache[T any](cfg *Config, key string) (T, error) {
 	})
 
 	if err != nil {
-		return r, gerror.ExtMsgf(err, "key="+key)
+		return r, gerror.ExtMsgf(err, "key="+k.key)
 	}
 
 	return v.(T), nil
 }
 
 func extractAndConvert[T any](m map[string]any, key string) (T, error) {
-	// TODO: check env overrides.
-
-	paths := strings.Split(key, ".")
 	result := *new(T)
-	v, ok := extract(m, paths)
+	v, ok := extract(m, strings.Split(key, "."))
 	if !ok {
 		return result, ErrConfigFailure.Msg("key `%s` not found", key)
 	}
 
-	bytes, err := yaml.Marshal(v)
-	if err != nil {
-		return result, ErrConfigFailure.Msg("key `%s` failed conversion back to yaml %+v", key, err)
-	}
-
-	err = yaml.Unmarshal(bytes, &result)
-	if err != nil {
-		return result, ErrConfigFailure.Convert(err)
-	}
-	return result, nil
+	err := (&converter{}).convert(reflect.ValueOf(&result).Elem(), v, key)
+	return result, err
 }
 
 func extract(m map[string]any, keys []string) (any, bool) {
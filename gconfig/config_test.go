@@ -16,7 +16,7 @@ import (
 	"github.com/drshriveer/gtools/gconfig"
 )
 
-//go:embed internal/*.yaml
+//go:embed internal/*.yaml internal/test_formats.*
 var testFS embed.FS
 
 type testStruct struct {
@@ -28,13 +28,15 @@ type testStruct struct {
 }
 
 func TestFlagParsing(t *testing.T) {
-	flag.Parse()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
 	builder := gconfig.NewBuilder().
 		WithDimension("d1", internal.D1a).
-		WithDimension("d2", internal.D2a)
+		WithDimension("d2", internal.D2a).
+		WithFlagSet(fs)
+	assert.Nil(t, flag.Lookup("d1"), "the global flag set must not be modified")
 
-	require.NoError(t, flag.Set("d1", internal.D1c.String()))
-	require.NoError(t, flag.Set("d2", internal.D2e.String()))
+	require.NoError(t, fs.Set("d1", internal.D1c.String()))
+	require.NoError(t, fs.Set("d2", internal.D2e.String()))
 	cfg, err := builder.FromFile(testFS, "internal/test.yaml")
 	require.NoError(t, err)
 	assert.Equal(t, internal.D1c, gconfig.GetDimension[internal.DimensionOne](cfg))
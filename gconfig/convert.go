@@ -0,0 +1,547 @@
+package gconfig
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/drshriveer/gtools/genum"
+)
+
+var (
+	durationType                = reflect.TypeFor[time.Duration]()
+	enumType                    = reflect.TypeFor[genum.Enum]()
+	textUnmarshalerType         = reflect.TypeFor[encoding.TextUnmarshaler]()
+	yamlUnmarshalerType         = reflect.TypeFor[yaml.Unmarshaler]()
+	obsoleteYAMLUnmarshalerType = reflect.TypeFor[obsoleteYAMLUnmarshaler]()
+
+	// structFieldsCache caches the fields of struct types by reflect.Type.
+	structFieldsCache sync.Map
+)
+
+// obsoleteYAMLUnmarshaler is the yaml.v2 style unmarshaler yaml.v3 still honors.
+type obsoleteYAMLUnmarshaler interface {
+	UnmarshalYAML(unmarshal func(any) error) error
+}
+
+// converter converts raw configuration values into go values. Get and Bind share it, so a
+// subtree converts to the same value either way:
+//   - struct fields are read from their `gconfig` tag, `yaml` tag, or lower-cased name;
+//     embedded structs without a name, and fields tagged `yaml:",inline"`, are inlined,
+//   - genum enums, yaml.Unmarshaler, and encoding.TextUnmarshaler implementations are honored,
+//   - durations are parsed from strings; numbers convert between numeric types when they fit,
+//   - numbers and booleans are parsed from strings, and any scalar can be read as a string,
+//   - a string of the form `[a, b]` can be read as a list.
+type converter struct {
+	// bind enables Bind's field handling: `default` tags, the `required` option, and
+	// case-insensitive keys.
+	bind      bool
+	templates templateResolvers
+}
+
+// convertInto converts a raw configuration value into the value target points to.
+func convertInto(v any, target any) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return ErrConfigFailure.Msg("cannot convert into non-pointer %T", target)
+	}
+	return (&converter{}).convert(rv.Elem(), v, "")
+}
+
+func (c *converter) convert(rv reflect.Value, node any, key string) error {
+	if node == nil {
+		switch rv.Kind() {
+		case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice:
+			rv.SetZero()
+		}
+		return nil
+	}
+
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return c.convert(rv.Elem(), node, key)
+	}
+
+	if ok, err := convertSpecial(rv, node, key); ok {
+		return err
+	}
+
+	switch v := node.(type) {
+	case map[string]any, map[any]any:
+		return c.convertMapping(rv, node, key)
+	case []any:
+		return c.convertSequence(rv, v, key)
+	case string:
+		if items, ok := splitList(v); ok && (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) {
+			return c.convertSequence(rv, items, key)
+		}
+	}
+	return convertScalar(rv, node, key)
+}
+
+// convertSpecial handles types that convert values themselves.
+func convertSpecial(rv reflect.Value, node any, key string) (bool, error) {
+	t := rv.Type()
+	switch {
+	case t.Implements(enumType):
+		// genum enums may be parsed by their traits, so parse the raw value.
+		e, err := reflect.Zero(t).Interface().(genum.Enum).ParseGeneric(node)
+		if err != nil {
+			if s, ok := scalarText(node); ok && s != node {
+				e, err = reflect.Zero(t).Interface().(genum.Enum).ParseGeneric(s)
+			}
+		}
+		if err != nil {
+			return true, keyErr(key, err)
+		}
+		rv.Set(reflect.ValueOf(e))
+		return true, nil
+	case t == durationType:
+		// durations are only parsed from strings; a bare number of nanoseconds is almost always a mistake.
+		s, ok := node.(string)
+		if !ok {
+			return true, convertErr(key, rv, node)
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return true, keyErr(key, err)
+		}
+		rv.SetInt(int64(d))
+		return true, nil
+	case !rv.CanAddr():
+		return false, nil
+	case reflect.PointerTo(t).Implements(yamlUnmarshalerType):
+		n := &yaml.Node{}
+		if err := n.Encode(node); err != nil {
+			return true, keyErr(key, err)
+		}
+		if err := rv.Addr().Interface().(yaml.Unmarshaler).UnmarshalYAML(n); err != nil {
+			return true, keyErr(key, err)
+		}
+		return true, nil
+	case reflect.PointerTo(t).Implements(obsoleteYAMLUnmarshalerType):
+		// rare enough not to be worth converting directly.
+		bytes, err := yaml.Marshal(node)
+		if err != nil {
+			return true, keyErr(key, err)
+		}
+		if err := yaml.Unmarshal(bytes, rv.Addr().Interface()); err != nil {
+			return true, keyErr(key, err)
+		}
+		return true, nil
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		s, ok := scalarText(node)
+		if !ok {
+			return false, nil
+		}
+		if err := rv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			return true, keyErr(key, err)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (c *converter) convertMapping(rv reflect.Value, node any, key string) error {
+	switch rv.Kind() {
+	case reflect.Interface:
+		if rv.NumMethod() > 0 {
+			return convertErr(key, rv, node)
+		}
+		// never hand out the configuration's own maps.
+		rv.Set(reflect.ValueOf(deepCopy(node)))
+		return nil
+	case reflect.Map:
+		entries, _ := mapEntries(node)
+		m := reflect.MakeMapWithSize(rv.Type(), len(entries))
+		for k, v := range entries {
+			mapKey := reflect.New(rv.Type().Key()).Elem()
+			if err := c.convert(mapKey, k, key); err != nil {
+				return err
+			}
+			mapValue := reflect.New(rv.Type().Elem()).Elem()
+			if err := c.convert(mapValue, v, joinKey(key, k)); err != nil {
+				return err
+			}
+			m.SetMapIndex(mapKey, mapValue)
+		}
+		rv.Set(m)
+		return nil
+	case reflect.Struct:
+		return c.convertStruct(rv, node, key)
+	default:
+		return convertErr(key, rv, node)
+	}
+}
+
+func (c *converter) convertStruct(rv reflect.Value, node any, key string) error {
+	entries, _ := mapEntries(node)
+	fields := structFieldsOf(rv.Type())
+	used := make(map[string]bool, len(fields.fields))
+	for _, field := range fields.fields {
+		fieldKey := joinKey(key, field.name)
+		entryKey, v, found := c.lookup(entries, field.name)
+		if found {
+			used[entryKey] = true
+		}
+		if c.bind && (!found || v == nil) {
+			if field.defaultVal == nil {
+				if field.required {
+					return ErrConfigFailure.Msg("required key `%s` is missing", fieldKey)
+				}
+				continue
+			}
+			resolved, err := parseTemplatedElements(c.templates, *field.defaultVal)
+			if err != nil {
+				return err
+			}
+			v, found = resolved, true
+		}
+		if !found {
+			continue
+		}
+		if err := c.convert(rv.FieldByIndex(field.index), v, fieldKey); err != nil {
+			return err
+		}
+	}
+
+	if fields.inlineMap == nil {
+		return nil // unknown keys are ignored, as they are by yaml.
+	}
+	inlineMap := rv.FieldByIndex(fields.inlineMap)
+	for k, v := range entries {
+		if used[k] {
+			continue
+		}
+		if inlineMap.IsNil() {
+			inlineMap.Set(reflect.MakeMap(inlineMap.Type()))
+		}
+		mapValue := reflect.New(inlineMap.Type().Elem()).Elem()
+		if err := c.convert(mapValue, v, joinKey(key, k)); err != nil {
+			return err
+		}
+		inlineMap.SetMapIndex(reflect.ValueOf(k).Convert(inlineMap.Type().Key()), mapValue)
+	}
+	return nil
+}
+
+// lookup finds the entry of a struct field, falling back to a case-insensitive match when binding.
+func (c *converter) lookup(entries map[string]any, name string) (string, any, bool) {
+	if v, ok := entries[name]; ok {
+		return name, v, true
+	}
+	if !c.bind {
+		return "", nil, false
+	}
+	for k, v := range entries {
+		if strings.EqualFold(k, name) {
+			return k, v, true
+		}
+	}
+	return "", nil, false
+}
+
+func (c *converter) convertSequence(rv reflect.Value, items []any, key string) error {
+	switch rv.Kind() {
+	case reflect.Interface:
+		if rv.NumMethod() > 0 {
+			return convertErr(key, rv, items)
+		}
+		rv.Set(reflect.ValueOf(deepCopy(items)))
+		return nil
+	case reflect.Slice:
+		slice := reflect.MakeSlice(rv.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := c.convert(slice.Index(i), item, joinKey(key, strconv.Itoa(i))); err != nil {
+				return err
+			}
+		}
+		rv.Set(slice)
+		return nil
+	case reflect.Array:
+		if len(items) != rv.Len() {
+			return ErrConfigFailure.Msg("key `%s`: invalid array: want %d elements but got %d",
+				key, rv.Len(), len(items))
+		}
+		for i, item := range items {
+			if err := c.convert(rv.Index(i), item, joinKey(key, strconv.Itoa(i))); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return convertErr(key, rv, items)
+	}
+}
+
+func convertScalar(rv reflect.Value, node any, key string) error {
+	nv := reflect.ValueOf(node)
+	if nv.Type() == rv.Type() {
+		rv.Set(nv)
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		if s, ok := scalarText(node); ok {
+			rv.SetString(s)
+			return nil
+		}
+	case reflect.Interface:
+		if nv.Type().AssignableTo(rv.Type()) {
+			rv.Set(nv)
+			return nil
+		}
+	case reflect.Bool:
+		if b, ok := toBool(node); ok {
+			rv.SetBool(b)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, ok := toInt64(node); ok && !rv.OverflowInt(i) {
+			rv.SetInt(i)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u, ok := toUint64(node); ok && !rv.OverflowUint(u) {
+			rv.SetUint(u)
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, ok := toFloat64(node); ok && !rv.OverflowFloat(f) {
+			rv.SetFloat(f)
+			return nil
+		}
+	}
+	return convertErr(key, rv, node)
+}
+
+func convertErr(key string, rv reflect.Value, node any) error {
+	if key == "" {
+		return ErrConfigFailure.Msg("cannot convert %T `%v` into %s", node, node, rv.Type())
+	}
+	return ErrConfigFailure.Msg("key `%s`: cannot convert %T `%v` into %s", key, node, node, rv.Type())
+}
+
+func keyErr(key string, err error) error {
+	if key == "" {
+		return ErrConfigFailure.Convert(err)
+	}
+	return ErrConfigFailure.Msg("key `%s`: %s", key, errMessage(err))
+}
+
+// mapEntries returns the entries of a map node with stringified keys.
+func mapEntries(node any) (map[string]any, bool) {
+	switch v := node.(type) {
+	case map[string]any:
+		return v, true
+	case map[any]any:
+		result := make(map[string]any, len(v))
+		for k, el := range v {
+			result[fmt.Sprint(k)] = el
+		}
+		return result, true
+	}
+	return nil, false
+}
+
+// splitList splits a string of the form `[a, b]` into its items.
+func splitList(s string) ([]any, bool) {
+	inner, ok := strings.CutPrefix(strings.TrimSpace(s), "[")
+	if !ok {
+		return nil, false
+	}
+	if inner, ok = strings.CutSuffix(inner, "]"); !ok {
+		return nil, false
+	}
+	if strings.TrimSpace(inner) == "" {
+		return []any{}, true
+	}
+	parts := strings.Split(inner, ",")
+	result := make([]any, len(parts))
+	for i, p := range parts {
+		result[i] = strings.TrimSpace(p)
+	}
+	return result, true
+}
+
+// scalarText returns the text of a scalar, as it would be written in yaml.
+func scalarText(node any) (string, bool) {
+	switch v := node.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	case float64:
+		switch s := strconv.FormatFloat(v, 'g', -1, 64); s {
+		case "+Inf":
+			return ".inf", true
+		case "-Inf":
+			return "-.inf", true
+		case "NaN":
+			return ".nan", true
+		default:
+			return s, true
+		}
+	case time.Time:
+		return v.Format(time.RFC3339Nano), true
+	}
+	return "", false
+}
+
+func toBool(node any) (bool, bool) {
+	switch v := node.(type) {
+	case bool:
+		return v, true
+	case string:
+		// yaml 1.1 booleans are accepted as well, as they are by yaml.v3 when unmarshaling into a bool.
+		switch v {
+		case "y", "Y", "yes", "Yes", "YES", "on", "On", "ON":
+			return true, true
+		case "n", "N", "no", "No", "NO", "off", "Off", "OFF":
+			return false, true
+		}
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	}
+	return false, false
+}
+
+func toInt64(node any) (int64, bool) {
+	switch v := node.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint64:
+		return int64(v), v <= 1<<63-1
+	case float64:
+		return int64(v), v == float64(int64(v))
+	case string:
+		i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		return i, err == nil
+	}
+	return 0, false
+}
+
+func toUint64(node any) (uint64, bool) {
+	switch v := node.(type) {
+	case int:
+		return uint64(v), v >= 0
+	case int64:
+		return uint64(v), v >= 0
+	case uint64:
+		return v, true
+	case float64:
+		return uint64(v), v >= 0 && v == float64(uint64(v))
+	case string:
+		u, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64)
+		return u, err == nil
+	}
+	return 0, false
+}
+
+func toFloat64(node any) (float64, bool) {
+	switch v := node.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// structFields are the fields of a struct type as the converter sees them.
+type structFields struct {
+	fields    []structField
+	inlineMap []int
+}
+
+type structField struct {
+	name       string
+	index      []int
+	required   bool
+	defaultVal *string
+}
+
+// structFieldsOf returns the (cached) fields of a struct type.
+func structFieldsOf(t reflect.Type) *structFields {
+	if cached, ok := structFieldsCache.Load(t); ok {
+		return cached.(*structFields)
+	}
+	fields := &structFields{}
+	collectStructFields(fields, t, nil, make(map[string]bool))
+	cached, _ := structFieldsCache.LoadOrStore(t, fields)
+	return cached.(*structFields)
+}
+
+func collectStructFields(fields *structFields, t reflect.Type, index []int, seen map[string]bool) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if tag, ok := field.Tag.Lookup("gconfig"); ok && tag == "-" {
+			continue
+		}
+		yamlTag := field.Tag.Get("yaml")
+		if yamlTag == "" && !strings.Contains(string(field.Tag), ":") {
+			yamlTag = string(field.Tag) // yaml accepts a bare tag as the name.
+		}
+		yamlName, yamlOpts, _ := strings.Cut(yamlTag, ",")
+		if yamlName == "-" {
+			continue
+		}
+		name, opts, tagged := parseTag(field)
+		inline := !tagged && (strings.Contains(","+yamlOpts+",", ",inline,") ||
+			(field.Anonymous && yamlName == "" && field.Type.Kind() == reflect.Struct))
+		if !field.IsExported() && !inline {
+			continue
+		}
+
+		fieldIndex := append(index[:len(index):len(index)], i)
+		if inline {
+			switch field.Type.Kind() {
+			case reflect.Struct:
+				collectStructFields(fields, field.Type, fieldIndex, seen)
+			case reflect.Map:
+				fields.inlineMap = fieldIndex
+			}
+			continue
+		}
+		if !tagged {
+			name = yamlName
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		f := structField{name: name, index: fieldIndex, required: slices.Contains(opts, "required")}
+		if defaultVal, ok := field.Tag.Lookup("default"); ok {
+			f.defaultVal = &defaultVal
+		}
+		fields.fields = append(fields.fields, f)
+	}
+}
@@ -0,0 +1,177 @@
+package gconfig
+
+import (
+	"math"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/drshriveer/gtools/gconfig/internal"
+)
+
+type convertTargetEmbedded struct {
+	Region string `yaml:"region"`
+}
+
+type convertTarget struct {
+	Name                  string
+	Port                  int           `yaml:"port"`
+	Timeout               time.Duration `yaml:"timeout"`
+	Ratio                 float32       `yaml:"ratio"`
+	Enabled               bool          `yaml:"enabled"`
+	Hosts                 []string      `yaml:"hosts"`
+	IP                    net.IP        `yaml:"ip"`
+	Dim                   internal.DimensionOne
+	Optional              *string        `yaml:"optional"`
+	Nested                *convertTarget `yaml:"nested"`
+	Tags                  map[string]any `yaml:"tags"`
+	Ignored               string         `yaml:"-"`
+	Rest                  map[string]any `yaml:",inline"`
+	convertTargetEmbedded `yaml:",inline"`
+}
+
+// TestConvertInto verifies direct conversion matches a yaml round-trip for values yaml can convert.
+func TestConvertInto(t *testing.T) {
+	value := map[string]any{
+		"Name":    "ignored, the yaml name is lower case",
+		"name":    "api",
+		"port":    8080,
+		"timeout": "5s",
+		"ratio":   0.5,
+		"enabled": "yes",
+		"hosts":   []any{"a.host", "b.host"},
+		"ip":      "127.0.0.1",
+		"dim":     "D1b",
+		"nested": map[string]any{
+			"name":    12,
+			"enabled": true,
+		},
+		"tags":    map[string]any{"team": "a", "tier": 1, "list": []any{1, "2"}},
+		"ignored": "value",
+		"extra":   1.5,
+		"region":  "eu",
+	}
+
+	tests := []struct {
+		description string
+		input       any
+		newTarget   func() any
+	}{
+		{description: "struct", input: value, newTarget: func() any { return new(convertTarget) }},
+		{description: "struct pointer", input: value, newTarget: func() any { return new(*convertTarget) }},
+		{description: "map", input: value, newTarget: func() any { return new(map[string]any) }},
+		{description: "any", input: value, newTarget: func() any { return new(any) }},
+		{description: "int into float", input: 3, newTarget: func() any { return new(float64) }},
+		{description: "float into int", input: 3.0, newTarget: func() any { return new(int8) }},
+		{description: "int into string", input: 3, newTarget: func() any { return new(string) }},
+		{description: "float into string", input: 1e21, newTarget: func() any { return new(string) }},
+		{description: "infinity into string", input: math.Inf(1), newTarget: func() any { return new(string) }},
+		{description: "bool into string", input: false, newTarget: func() any { return new(string) }},
+		{description: "duration", input: "1m3s", newTarget: func() any { return new(time.Duration) }},
+		{description: "list into array", input: []any{1, 2}, newTarget: func() any { return new([2]uint) }},
+		{description: "int keys", input: map[any]any{1: "a", 2: "b"}, newTarget: func() any { return new(map[int]string) }},
+		{description: "null", input: nil, newTarget: func() any { return new([]string) }},
+		{description: "enum", input: "D1c", newTarget: func() any { return new(internal.DimensionOne) }},
+		{description: "text unmarshaler", input: "::1", newTarget: func() any { return new(net.IP) }},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			expected := test.newTarget()
+			bytes, err := yaml.Marshal(test.input)
+			require.NoError(t, err)
+			require.NoError(t, yaml.Unmarshal(bytes, expected))
+
+			actual := test.newTarget()
+			require.NoError(t, convertInto(test.input, actual))
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestConvertInto_Errors(t *testing.T) {
+	tests := []struct {
+		description string
+		input       any
+		target      any
+	}{
+		{description: "word into int", input: "eighty", target: new(int)},
+		{description: "float string into int", input: "1.5", target: new(int)},
+		{description: "int into duration", input: 5, target: new(time.Duration)},
+		{description: "overflow", input: 300, target: new(int8)},
+		{description: "negative into uint", input: -1, target: new(uint)},
+		{description: "string into bool", input: "maybe", target: new(bool)},
+		{description: "map into int", input: map[string]any{"a": 1}, target: new(int)},
+		{description: "list into struct", input: []any{1}, target: new(convertTarget)},
+		{description: "array length", input: []any{1, 2, 3}, target: new([2]int)},
+		{description: "invalid enum", input: "D9", target: new(internal.DimensionOne)},
+		{description: "invalid text", input: "not-an-ip", target: new(net.IP)},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			bytes, err := yaml.Marshal(test.input)
+			require.NoError(t, err)
+			yamlTarget := reflect.New(reflect.TypeOf(test.target).Elem()).Interface()
+			require.Error(t, yaml.Unmarshal(bytes, yamlTarget), "yaml should also fail")
+
+			assert.ErrorIs(t, convertInto(test.input, test.target), ErrConfigFailure)
+		})
+	}
+}
+
+func TestConvertInto_Strings(t *testing.T) {
+	tests := []struct {
+		description string
+		input       string
+		newTarget   func() any
+		expected    any
+	}{
+		{description: "int", input: "8080", newTarget: func() any { return new(int) }, expected: ptr(8080)},
+		{description: "leading zero", input: "0123", newTarget: func() any { return new(uint) }, expected: ptr(uint(123))},
+		{description: "float", input: "1e3", newTarget: func() any { return new(float64) }, expected: ptr(1000.0)},
+		{description: "bool", input: "true", newTarget: func() any { return new(bool) }, expected: ptr(true)},
+		{description: "yaml 1.1 bool", input: "off", newTarget: func() any { return new(bool) }, expected: ptr(false)},
+		{
+			description: "list",
+			input:       "[a, b ,c]",
+			newTarget:   func() any { return new([]string) },
+			expected:    &[]string{"a", "b", "c"},
+		},
+		{
+			description: "list of ints",
+			input:       "[1, 2]",
+			newTarget:   func() any { return new([2]int) },
+			expected:    &[2]int{1, 2},
+		},
+		{description: "empty list", input: "[]", newTarget: func() any { return new([]int) }, expected: &[]int{}},
+		{
+			description: "list as string",
+			input:       "[a, b]",
+			newTarget:   func() any { return new(string) },
+			expected:    ptr("[a, b]"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			actual := test.newTarget()
+			require.NoError(t, convertInto(test.input, actual))
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestConvertInto_DoesNotShareData(t *testing.T) {
+	value := map[string]any{"list": []any{1, 2}}
+	var result any
+	require.NoError(t, convertInto(value, &result))
+	result.(map[string]any)["list"].([]any)[0] = 3
+	assert.Equal(t, 1, value["list"].([]any)[0])
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
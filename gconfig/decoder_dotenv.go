@@ -0,0 +1,82 @@
+package gconfig
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// dotEnvSeparator nests dotenv keys.
+const dotEnvSeparator = "__"
+
+func unmarshalDotEnv(b []byte) (map[string]any, error) {
+	data := make(map[string]any)
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, raw, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, ErrFailedParsing.Msg("dotenv line %d: expected KEY=VALUE", lineNum)
+		}
+		value, err := parseDotEnvValue(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, ErrFailedParsing.Msg("dotenv line %d: %s", lineNum, err)
+		}
+		if err := setNested(data, strings.Split(key, dotEnvSeparator), value); err != nil {
+			return nil, ErrFailedParsing.Msg("dotenv line %d: %s", lineNum, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, ErrFailedParsing.Convert(err)
+	}
+	return data, nil
+}
+
+// parseDotEnvValue unquotes quoted values and removes trailing comments from anything else.
+// Values are strings, converted to the type they are fetched as like environment overrides.
+func parseDotEnvValue(raw string) (any, error) {
+	switch {
+	case strings.HasPrefix(raw, `"`):
+		return strconv.Unquote(raw)
+	case strings.HasPrefix(raw, `'`):
+		if len(raw) < 2 || !strings.HasSuffix(raw, `'`) {
+			return nil, strconv.ErrSyntax
+		}
+		return raw[1 : len(raw)-1], nil
+	}
+	if i := strings.Index(raw, " #"); i >= 0 {
+		raw = strings.TrimSpace(raw[:i])
+	}
+	return raw, nil
+}
+
+// setNested sets a value in nested maps, creating them as required.
+func setNested(data map[string]any, paths []string, value any) error {
+	for i, p := range paths[:len(paths)-1] {
+		next, ok := data[p]
+		if !ok {
+			next = make(map[string]any)
+			data[p] = next
+		}
+		m, ok := next.(map[string]any)
+		if !ok {
+			return fmt.Errorf("key %s is both a value and a parent of other keys",
+				strings.Join(paths[:i+1], dotEnvSeparator))
+		}
+		data = m
+	}
+	last := paths[len(paths)-1]
+	if _, ok := data[last]; ok {
+		return fmt.Errorf("key %s is defined more than once", strings.Join(paths, dotEnvSeparator))
+	}
+	data[last] = value
+	return nil
+}
@@ -0,0 +1,303 @@
+package gconfig
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// unmarshalHCL decodes a subset of HCL:
+//
+//	# comments, // comments, and /* comments */
+//	name = "value"              # attributes: strings, numbers, bools, null, lists, and objects
+//	hosts = ["a", "b"]
+//	tags = { team = "a", "tier" = 3 }
+//	clients {                   # blocks become nested maps
+//	  redis "primary" {         # and labels nest further: clients.redis.primary.address
+//	    address = "localhost:4090"
+//	  }
+//	}
+//
+// Blocks with the same name (and labels) are merged; attributes may only be defined once.
+func unmarshalHCL(b []byte) (map[string]any, error) {
+	p := &hclParser{src: []rune(string(b)), line: 1}
+	data := make(map[string]any)
+	if err := p.parseBody(data, false); err != nil {
+		return nil, ErrFailedParsing.Msg("hcl line %d: %s", p.line, err)
+	}
+	return data, nil
+}
+
+type hclParser struct {
+	src  []rune
+	pos  int
+	line int
+}
+
+// parseBody parses attributes and blocks until the end of input or, for a block, a closing brace.
+func (p *hclParser) parseBody(data map[string]any, inBlock bool) error {
+	for {
+		p.skipSpace(true)
+		if p.eof() {
+			if inBlock {
+				return fmt.Errorf("unexpected end of input, expected `}`")
+			}
+			return nil
+		}
+		if p.peek() == '}' {
+			if !inBlock {
+				return fmt.Errorf("unexpected `}`")
+			}
+			p.pos++
+			return nil
+		}
+
+		name, err := p.parseKey()
+		if err != nil {
+			return err
+		}
+		p.skipSpace(false)
+		if p.peek() == '=' {
+			p.pos++
+			value, err := p.parseValue()
+			if err != nil {
+				return err
+			}
+			if _, ok := data[name]; ok {
+				return fmt.Errorf("attribute `%s` is defined more than once", name)
+			}
+			data[name] = value
+			continue
+		}
+
+		// otherwise this is a block, with optional labels.
+		paths := []string{name}
+		for p.skipSpace(false); p.peek() == '"'; p.skipSpace(false) {
+			label, err := p.parseString()
+			if err != nil {
+				return err
+			}
+			paths = append(paths, label)
+		}
+		if p.peek() != '{' {
+			return fmt.Errorf("expected `=` or `{` after `%s`", name)
+		}
+		p.pos++
+		block, err := blockFor(data, paths)
+		if err != nil {
+			return err
+		}
+		if err := p.parseBody(block, true); err != nil {
+			return err
+		}
+	}
+}
+
+// blockFor returns the map for a block, creating it or merging into an existing one.
+func blockFor(data map[string]any, paths []string) (map[string]any, error) {
+	for _, name := range paths {
+		next, ok := data[name]
+		if !ok {
+			next = make(map[string]any)
+			data[name] = next
+		}
+		m, ok := next.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("block `%s` conflicts with an attribute of the same name", name)
+		}
+		data = m
+	}
+	return data, nil
+}
+
+func (p *hclParser) parseKey() (string, error) {
+	if p.peek() == '"' {
+		return p.parseString()
+	}
+	start := p.pos
+	for !p.eof() && isHCLIdentRune(p.peek()) {
+		p.pos++
+	}
+	if start == p.pos {
+		return "", fmt.Errorf("unexpected `%c`, expected a name", p.peek())
+	}
+	return string(p.src[start:p.pos]), nil
+}
+
+func (p *hclParser) parseValue() (any, error) {
+	p.skipSpace(false)
+	if p.eof() {
+		return nil, fmt.Errorf("unexpected end of input, expected a value")
+	}
+	switch r := p.peek(); {
+	case r == '"':
+		return p.parseString()
+	case r == '[':
+		return p.parseList()
+	case r == '{':
+		return p.parseObject()
+	case r == '-' || r == '+' || unicode.IsDigit(r):
+		return p.parseNumber()
+	default:
+		word, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+		switch word {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unsupported value `%s`", word)
+	}
+}
+
+func (p *hclParser) parseList() ([]any, error) {
+	p.pos++ // [
+	result := make([]any, 0)
+	for {
+		p.skipSpace(true)
+		if p.peek() == ']' {
+			p.pos++
+			return result, nil
+		}
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+		p.skipSpace(true)
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return nil, fmt.Errorf("expected `,` or `]` in list")
+		}
+	}
+}
+
+func (p *hclParser) parseObject() (map[string]any, error) {
+	p.pos++ // {
+	result := make(map[string]any)
+	for {
+		p.skipSpace(true)
+		if p.peek() == '}' {
+			p.pos++
+			return result, nil
+		}
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace(false)
+		if r := p.peek(); r != '=' && r != ':' {
+			return nil, fmt.Errorf("expected `=` or `:` after `%s`", key)
+		}
+		p.pos++
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		result[key] = v
+		p.skipSpace(true)
+		if p.peek() == ',' {
+			p.pos++
+		}
+	}
+}
+
+func (p *hclParser) parseString() (string, error) {
+	start := p.pos
+	p.pos++ // opening quote.
+	for !p.eof() && p.peek() != '"' {
+		if p.peek() == '\n' {
+			return "", fmt.Errorf("unterminated string")
+		}
+		if p.peek() == '\\' {
+			p.pos++
+		}
+		p.pos++
+	}
+	if p.eof() {
+		return "", fmt.Errorf("unterminated string")
+	}
+	p.pos++ // closing quote.
+	s, err := strconv.Unquote(string(p.src[start:p.pos]))
+	if err != nil {
+		return "", fmt.Errorf("invalid string %s", string(p.src[start:p.pos]))
+	}
+	return s, nil
+}
+
+func (p *hclParser) parseNumber() (any, error) {
+	start := p.pos
+	p.pos++
+	for !p.eof() && strings.ContainsRune("0123456789.eE+-_xXabcdefABCDEF", p.peek()) {
+		p.pos++
+	}
+	s := string(p.src[start:p.pos])
+	if i, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return normalize(i), nil
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, nil
+	}
+	return nil, fmt.Errorf("invalid number `%s`", s)
+}
+
+// skipSpace skips whitespace and comments, and also newlines and commas-free line breaks if
+// newlines is true.
+func (p *hclParser) skipSpace(newlines bool) {
+	for !p.eof() {
+		r := p.peek()
+		switch {
+		case r == '\n':
+			if !newlines {
+				return
+			}
+			p.line++
+			p.pos++
+		case unicode.IsSpace(r):
+			p.pos++
+		case r == '#' || (r == '/' && p.peekAt(1) == '/'):
+			for !p.eof() && p.peek() != '\n' {
+				p.pos++
+			}
+		case r == '/' && p.peekAt(1) == '*':
+			p.pos += 2
+			for !p.eof() && (p.peek() != '*' || p.peekAt(1) != '/') {
+				if p.peek() == '\n' {
+					p.line++
+				}
+				p.pos++
+			}
+			p.pos += 2
+		default:
+			return
+		}
+	}
+}
+
+func (p *hclParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *hclParser) peek() rune {
+	return p.peekAt(0)
+}
+
+func (p *hclParser) peekAt(offset int) rune {
+	if p.pos+offset >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos+offset]
+}
+
+func isHCLIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '@'
+}
@@ -0,0 +1,164 @@
+package gconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Decoder decodes the bytes of a configuration file into the structure gconfig works with:
+// nested map[string]any values, []any lists, and scalars (string, int, float64, bool, nil).
+// Dimension reduction, templates, and typed access work identically for every format.
+type Decoder interface {
+	Decode(bytes []byte) (map[string]any, error)
+}
+
+// DecoderFunc adapts a function to a Decoder.
+type DecoderFunc func(bytes []byte) (map[string]any, error)
+
+// Decode calls the function.
+func (f DecoderFunc) Decode(bytes []byte) (map[string]any, error) {
+	return f(bytes)
+}
+
+var (
+	// YAMLDecoder decodes yaml; it is the default for files without a registered extension.
+	YAMLDecoder Decoder = DecoderFunc(unmarshalYAML)
+	// JSONDecoder decodes json.
+	JSONDecoder Decoder = DecoderFunc(unmarshalJSON)
+	// TOMLDecoder decodes toml.
+	TOMLDecoder Decoder = DecoderFunc(unmarshalTOML)
+	// DotEnvDecoder decodes dotenv files. Keys are nested by `__`, e.g. `CLIENTS__REDIS__ADDRESS`, and
+	// values are strings converted to the type they are fetched as, like environment overrides.
+	DotEnvDecoder Decoder = DecoderFunc(unmarshalDotEnv)
+	// HCLDecoder decodes a subset of HCL: attributes, (labeled) blocks, lists, and objects.
+	// Expressions, functions, and interpolation are not supported.
+	HCLDecoder Decoder = DecoderFunc(unmarshalHCL)
+)
+
+// WithDecoder registers the decoder for files with an extension, e.g. `.json`. Registering an
+// extension again replaces its decoder; the empty extension sets the decoder for files with
+// an unknown extension (and for FromBytes).
+// By default: `.json` is decoded as json, `.toml` as toml, `.env` as dotenv, `.hcl` as HCL,
+// and everything else as yaml.
+func (b *Builder) WithDecoder(ext string, decoder Decoder) *Builder {
+	result := make(decoders, len(b.decoders)+1)
+	for k, v := range b.decoders {
+		result[k] = v
+	}
+	result[strings.ToLower(ext)] = decoder
+	b.decoders = result
+	return b
+}
+
+// decoders is a registry of decoders by file extension.
+type decoders map[string]Decoder
+
+func defaultDecoders() decoders {
+	return decoders{
+		"":      YAMLDecoder,
+		".yaml": YAMLDecoder,
+		".yml":  YAMLDecoder,
+		".json": JSONDecoder,
+		".toml": TOMLDecoder,
+		".env":  DotEnvDecoder,
+		".hcl":  HCLDecoder,
+	}
+}
+
+// DecoderForPath returns the decoder used by default for a file by its extension, e.g. JSONDecoder
+// for `config.json`; files with an unknown extension are decoded as yaml.
+func DecoderForPath(path string) Decoder {
+	return defaultDecoders().forName(path)
+}
+
+// forName returns the decoder for a file name by its extension.
+func (d decoders) forName(name string) Decoder {
+	if decoder, ok := d[strings.ToLower(filepath.Ext(name))]; ok {
+		return decoder
+	}
+	if decoder, ok := d[""]; ok {
+		return decoder
+	}
+	return YAMLDecoder
+}
+
+// decode decodes bytes and normalizes the result.
+func decode(decoder Decoder, bytes []byte) (map[string]any, error) {
+	data, err := decoder.Decode(bytes)
+	if err != nil {
+		return nil, ErrFailedParsing.Convert(err)
+	}
+	if data == nil {
+		return make(map[string]any), nil
+	}
+	return normalize(data).(map[string]any), nil
+}
+
+func unmarshalYAML(bytes []byte) (map[string]any, error) {
+	data := make(map[string]any)
+	if err := yaml.Unmarshal(bytes, &data); err != nil {
+		return nil, ErrFailedParsing.Convert(err)
+	}
+	return data, nil
+}
+
+func unmarshalJSON(b []byte) (map[string]any, error) {
+	data := make(map[string]any)
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil {
+		return nil, ErrFailedParsing.Convert(err)
+	}
+	return data, nil
+}
+
+func unmarshalTOML(bytes []byte) (map[string]any, error) {
+	data := make(map[string]any)
+	if err := toml.Unmarshal(bytes, &data); err != nil {
+		return nil, ErrFailedParsing.Convert(err)
+	}
+	return data, nil
+}
+
+// normalize converts the types decoders produce to those yaml does, so values behave the same
+// regardless of the format they were read from.
+func normalize(in any) any {
+	switch v := in.(type) {
+	case map[string]any:
+		for k, el := range v {
+			v[k] = normalize(el)
+		}
+	case map[any]any:
+		for k, el := range v {
+			v[k] = normalize(el)
+		}
+	case []any:
+		for i, el := range v {
+			v[i] = normalize(el)
+		}
+	case []map[string]any:
+		result := make([]any, len(v))
+		for i, el := range v {
+			result[i] = normalize(el)
+		}
+		return result
+	case int64:
+		if int64(int(v)) == v {
+			return int(v)
+		}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return normalize(i)
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	}
+	return in
+}
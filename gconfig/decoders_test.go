@@ -0,0 +1,149 @@
+package gconfig_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/gconfig"
+	"github.com/drshriveer/gtools/gconfig/internal"
+)
+
+func TestDecoders(t *testing.T) {
+	for _, ext := range []string{".yaml", ".json", ".toml", ".env", ".hcl"} {
+		t.Run(ext, func(t *testing.T) {
+			t.Parallel()
+			for _, test := range []struct {
+				d1           internal.DimensionOne
+				expectedPort int
+			}{
+				{d1: internal.D1a, expectedPort: 8080},
+				{d1: internal.D1b, expectedPort: 9090},
+			} {
+				cfg, err := gconfig.NewBuilder().
+					WithDimensionValue("fmt-d1", internal.D1c, test.d1).
+					FromFile(testFS, "internal/test_formats"+ext)
+				require.NoError(t, err)
+
+				assert.Equal(t, "api", gconfig.MustGet[string](cfg, "service.name"))
+				assert.Equal(t, test.expectedPort, gconfig.MustGet[int](cfg, "service.port"))
+				assert.Equal(t, 5*time.Second, gconfig.MustGet[time.Duration](cfg, "service.timeout"))
+				assert.Equal(t, 0.5, gconfig.MustGet[float64](cfg, "service.ratio"))
+				assert.True(t, gconfig.MustGet[bool](cfg, "service.enabled"))
+				assert.Equal(t, []string{"a.host", "b.host"}, gconfig.MustGet[[]string](cfg, "service.hosts"))
+				assert.Equal(t, "http://localhost:80", gconfig.MustGet[string](cfg, "service.url"))
+			}
+		})
+	}
+}
+
+func TestDecoderForPath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		path  string
+		input string
+	}{
+		{path: "config.yaml", input: "port: 1"},
+		{path: "dir/config.json", input: `{"port": 1}`},
+		{path: "CONFIG.TOML", input: "port = 1"},
+		{path: ".env", input: "port=1"},
+		{path: "config.hcl", input: "port = 1"},
+		{path: "config.conf", input: "port: 1"},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			t.Parallel()
+			data, err := gconfig.DecoderForPath(test.path).Decode([]byte(test.input))
+			require.NoError(t, err)
+			assert.Equal(t, "1", fmt.Sprint(data["port"]))
+		})
+	}
+}
+
+func TestBuilder_WithDecoder(t *testing.T) {
+	wrapped := gconfig.DecoderFunc(func(bytes []byte) (map[string]any, error) {
+		data, err := gconfig.YAMLDecoder.Decode(bytes)
+		return map[string]any{"wrapped": data}, err
+	})
+
+	cfg, err := gconfig.NewBuilder().
+		WithDecoder(".YAML", wrapped).
+		FromFile(testFS, "internal/test_formats.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "api", gconfig.MustGet[string](cfg, "wrapped.service.name"))
+
+	cfg, err = gconfig.NewBuilder().
+		WithDecoder("", gconfig.JSONDecoder).
+		FromBytes([]byte(`{"name": "json"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "json", gconfig.MustGet[string](cfg, "name"))
+
+	cfg, err = gconfig.NewBuilder().FromLayers(
+		gconfig.DecoderLayer("config", []byte("name = \"toml\""), gconfig.TOMLDecoder),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "toml", gconfig.MustGet[string](cfg, "name"))
+}
+
+func TestDecoders_Errors(t *testing.T) {
+	tests := []struct {
+		description string
+		decoder     gconfig.Decoder
+		input       string
+		expectedErr string
+	}{
+		{
+			description: "dotenv without a value",
+			decoder:     gconfig.DotEnvDecoder,
+			input:       "# comment\nNAME",
+			expectedErr: "dotenv line 2: expected KEY=VALUE",
+		},
+		{
+			description: "dotenv value and parent",
+			decoder:     gconfig.DotEnvDecoder,
+			input:       "A=1\nA__B=2",
+			expectedErr: "dotenv line 2: key A is both a value and a parent of other keys",
+		},
+		{
+			description: "dotenv duplicate key",
+			decoder:     gconfig.DotEnvDecoder,
+			input:       "A=1\nA=2",
+			expectedErr: "dotenv line 2: key A is defined more than once",
+		},
+		{
+			description: "hcl unterminated block",
+			decoder:     gconfig.HCLDecoder,
+			input:       "a {\n  b = 1\n",
+			expectedErr: "hcl line 3: unexpected end of input, expected `}`",
+		},
+		{
+			description: "hcl duplicate attribute",
+			decoder:     gconfig.HCLDecoder,
+			input:       "a = 1\na = 2",
+			expectedErr: "hcl line 2: attribute `a` is defined more than once",
+		},
+		{
+			description: "hcl expression",
+			decoder:     gconfig.HCLDecoder,
+			input:       "a = var.b",
+			expectedErr: "hcl line 1: unsupported value `var`",
+		},
+		{
+			description: "json syntax",
+			decoder:     gconfig.JSONDecoder,
+			input:       "{",
+			expectedErr: "unexpected EOF",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			_, err := gconfig.NewBuilder().FromLayers(
+				gconfig.DecoderLayer("broken", []byte(test.input), test.decoder))
+			assert.ErrorIs(t, err, gconfig.ErrFailedParsing)
+			assert.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package gconfig_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/gconfig"
+	"github.com/drshriveer/gtools/gconfig/internal"
+)
+
+func TestWithExplicitDimensionKeys(t *testing.T) {
+	tests := []struct {
+		description string
+		d1          internal.DimensionOne
+		d2          internal.DimensionTwo
+
+		expectedTimeout time.Duration
+		expectedNested  string
+	}{
+		{
+			description:     "marked keys in any dimension order",
+			d1:              internal.D1a,
+			d2:              internal.D2b,
+			expectedTimeout: time.Second,
+			expectedNested:  "d2b-d1a",
+		},
+		{
+			description:     "marked defaults",
+			d1:              internal.D1c,
+			d2:              internal.D2b,
+			expectedTimeout: 2 * time.Second,
+			expectedNested:  "d2b",
+		},
+		{
+			description:     "unmarked default",
+			d1:              internal.D1a,
+			d2:              internal.D2a,
+			expectedTimeout: time.Second,
+			expectedNested:  "other",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			cfg, err := gconfig.NewBuilder().
+				WithDimension("d1", test.d1).
+				WithDimension("d2", test.d2).
+				WithExplicitDimensionKeys().
+				FromFile(testFS, "internal/test_dimension_keys.yaml")
+			require.NoError(t, err)
+
+			assert.Equal(t,
+				map[internal.DimensionOne]string{internal.D1a: "a.host", internal.D1b: "b.host"},
+				gconfig.MustGet[map[internal.DimensionOne]string](cfg, "clients.addresses"))
+			assert.Equal(t, "literal", gconfig.MustGet[string](cfg, "clients.@escaped"))
+			assert.Equal(t, test.expectedTimeout, gconfig.MustGet[time.Duration](cfg, "clients.timeout"))
+			assert.Equal(t, test.expectedNested, gconfig.MustGet[string](cfg, "clients.nested"))
+		})
+	}
+}
+
+func TestDimensionKeys_Implicit(t *testing.T) {
+	// without explicit keys a map keyed by dimension values is a dimension switch.
+	cfg, err := gconfig.NewBuilder().
+		WithDimension("d1", internal.D1b).
+		WithDimension("d2", internal.D2b).
+		FromFile(testFS, "internal/test_dimension_keys.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, "b.host", gconfig.MustGet[string](cfg, "clients.addresses"))
+	assert.Equal(t, "literal", gconfig.MustGet[string](cfg, "clients.@escaped"))
+	assert.Equal(t, 2*time.Second, gconfig.MustGet[time.Duration](cfg, "clients.timeout"))
+	assert.Equal(t, "d2b", gconfig.MustGet[string](cfg, "clients.nested"))
+}
+
+func TestDimensionKeys_Errors(t *testing.T) {
+	tests := []struct {
+		description string
+		yaml        string
+		expectedErr string
+	}{
+		{
+			description: "marked keys mixed with map keys",
+			yaml:        "key:\n  '@D1a': a\n  D1b: b\n  other: c",
+			expectedErr: "ambiguous keys! dimension keys [@D1a] are mixed with map keys [D1b other]",
+		},
+		{
+			description: "marked keys of different dimensions",
+			yaml:        "key:\n  '@D1a': a\n  '@D2a': b",
+			expectedErr: "dimension keys [@D1a @D2a] are not all values of a single dimension",
+		},
+		{
+			description: "unknown dimension value",
+			yaml:        "key:\n  '@D9z': a",
+			expectedErr: "dimension keys [@D9z] are not all values of a single dimension",
+		},
+		{
+			description: "two defaults",
+			yaml:        "key:\n  '@D1a': a\n  '@default': b\n  default: c",
+			expectedErr: "dimension keys define both",
+		},
+		{
+			description: "no matching key or default",
+			yaml:        "key:\n  '@D1b': a",
+			expectedErr: "no `default` or `@D1a` value found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			_, err := gconfig.NewBuilder().
+				WithDimension("d1", internal.D1a).
+				WithDimension("d2", internal.D2a).
+				WithExplicitDimensionKeys().
+				FromBytes([]byte(test.yaml))
+			require.ErrorIs(t, err, gconfig.ErrFailedParsing)
+			assert.Contains(t, err.Error(), test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package gconfig
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/drshriveer/gtools/genum"
+)
+
+// DimensionResolver resolves the value of a dimension from a source such as a flag, the
+// hostname, or a file. It returns found=false if the source does not determine the value, in which
+// case the next resolver is consulted. defaultVal is the dimension's default, used to parse values.
+type DimensionResolver interface {
+	Resolve(defaultVal genum.Enum) (value genum.Enum, found bool, err error)
+}
+
+// DimensionResolverFunc adapts a function to a DimensionResolver.
+type DimensionResolverFunc func(defaultVal genum.Enum) (genum.Enum, bool, error)
+
+// Resolve calls the function.
+func (f DimensionResolverFunc) Resolve(defaultVal genum.Enum) (genum.Enum, bool, error) {
+	return f(defaultVal)
+}
+
+// WithDimensionResolvers adds a new dimension to switch configurations on whose value is determined
+// by resolvers, so deployments without flags can still select the right configuration, e.g.
+//
+//	WithDimensionResolvers("stage", environment.Development,
+//		gconfig.DimensionFromFlags(),
+//		gconfig.DimensionFromEnv("STAGE"),
+//		gconfig.DimensionFromFile("/etc/stage"),
+//		gconfig.DimensionFromHostname(regexp.MustCompile(`^\w+-(\w+)-\d+$`)),
+//	)
+//
+// Resolvers are consulted when the configuration is built, in order of precedence: the first to find
+// a value wins, and defaultVal is used if none does. A resolver that fails, or finds a value that is
+// not valid for the dimension, fails building the configuration.
+func (b *Builder) WithDimensionResolvers(name string, defaultVal genum.Enum, resolvers ...DimensionResolver) *Builder {
+	d := &dimension{
+		defaultVal: defaultVal,
+		flagName:   name,
+		parsed:     defaultVal,
+		resolvers:  resolvers,
+		flagged:    slices.ContainsFunc(resolvers, isFlagResolver),
+	}
+	if d.flagged && b.flags != nil {
+		b.flags.register(d)
+	}
+	b.dimensions = append(b.dimensions, d)
+	return b
+}
+
+// resolve returns a copy of the dimension set from the first of its resolvers to find a value.
+// Dimensions without resolvers keep their value.
+func (d *dimension) resolve(flags flagSet) (*dimension, error) {
+	resolved := *d
+	for _, r := range d.resolvers {
+		if fr, ok := r.(flagResolver); ok {
+			fr.name, fr.flags = d.flagName, flags
+			r = fr
+		}
+		v, found, err := r.Resolve(d.defaultVal)
+		if err != nil {
+			return nil, ErrFailedParsing.Msg("dimension %s: %s", d.flagName, errMessage(err))
+		}
+		if !found {
+			continue
+		}
+		if reflect.TypeOf(v) != reflect.TypeOf(d.defaultVal) {
+			return nil, ErrFailedParsing.Msg("dimension %s value %T must be the same type as its default %T",
+				d.flagName, v, d.defaultVal)
+		}
+		resolved.parsed = v
+		break
+	}
+	return &resolved, nil
+}
+
+// DimensionFromFlags resolves a dimension from the flag named after it in the flag set given to
+// WithFlagSet or WithPFlags, if the flag was set. The flag is registered like those of WithDimension;
+// building the configuration fails if there is no flag set.
+func DimensionFromFlags() DimensionResolver {
+	return flagResolver{}
+}
+
+// flagResolver is bound to its dimension's flag when the configuration is built.
+type flagResolver struct {
+	name  string
+	flags flagSet
+	// optional, if true, finds no value rather than failing when there is no flag set.
+	optional bool
+}
+
+func (r flagResolver) Resolve(defaultVal genum.Enum) (genum.Enum, bool, error) {
+	if r.flags == nil {
+		if r.optional {
+			return nil, false, nil
+		}
+		return nil, false, errors.New("DimensionFromFlags requires WithFlagSet or WithPFlags")
+	}
+	s, ok := r.flags.lookup(r.name)
+	if !ok {
+		return nil, false, nil
+	}
+	return parseDimension(defaultVal, s, "flag "+r.name)
+}
+
+func isFlagResolver(r DimensionResolver) bool {
+	_, ok := r.(flagResolver)
+	return ok
+}
+
+// DimensionFromEnv resolves a dimension from an environment variable, if it is set.
+func DimensionFromEnv(variable string) DimensionResolver {
+	return DimensionResolverFunc(func(defaultVal genum.Enum) (genum.Enum, bool, error) {
+		s, ok := lookupEnv(variable)
+		if !ok {
+			return nil, false, nil
+		}
+		return parseDimension(defaultVal, s, "environment variable "+variable)
+	})
+}
+
+// DimensionFromFile resolves a dimension from the contents of a file, e.g. `/etc/stage`, if it exists.
+// Surrounding whitespace is ignored.
+func DimensionFromFile(filename string) DimensionResolver {
+	return DimensionResolverFunc(func(defaultVal genum.Enum) (genum.Enum, bool, error) {
+		//nolint:gosec // G304 the file is chosen by the caller.
+		contents, err := os.ReadFile(filename)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return parseDimension(defaultVal, strings.TrimSpace(string(contents)), "file "+filename)
+	})
+}
+
+// DimensionFromHostname resolves a dimension from the machine's hostname with a pattern, e.g.
+// `^api-(\w+)-\d+$` resolves `EU` from `api-EU-3`. The value is the subexpression named `value` if
+// there is one, otherwise the first subexpression, otherwise the whole match.
+// If the pattern does not match the hostname the value is not found.
+func DimensionFromHostname(pattern *regexp.Regexp, opts ...HostnameOption) DimensionResolver {
+	o := hostnameOptions{hostname: os.Hostname}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return DimensionResolverFunc(func(defaultVal genum.Enum) (genum.Enum, bool, error) {
+		host, err := o.hostname()
+		if err != nil {
+			return nil, false, err
+		}
+		match := pattern.FindStringSubmatch(host)
+		if match == nil {
+			return nil, false, nil
+		}
+		value := match[0]
+		if i := pattern.SubexpIndex("value"); i > 0 {
+			value = match[i]
+		} else if len(match) > 1 {
+			value = match[1]
+		}
+		return parseDimension(defaultVal, value, "hostname "+host)
+	})
+}
+
+// HostnameOption configures DimensionFromHostname.
+type HostnameOption func(o *hostnameOptions)
+
+type hostnameOptions struct {
+	hostname func() (string, error)
+}
+
+// HostnameFunc replaces os.Hostname as the source of the hostname, e.g. to read it from an
+// environment variable set by an orchestrator.
+func HostnameFunc(fn func() (string, error)) HostnameOption {
+	return func(o *hostnameOptions) {
+		o.hostname = fn
+	}
+}
+
+// DimensionFromFunc resolves a dimension with a function. A nil value is not found; an invalid one
+// fails building the configuration.
+func DimensionFromFunc(fn func() (genum.Enum, error)) DimensionResolver {
+	return DimensionResolverFunc(func(genum.Enum) (genum.Enum, bool, error) {
+		v, err := fn()
+		if err != nil || v == nil {
+			return nil, false, err
+		}
+		if !v.IsValid() {
+			return nil, false, fmt.Errorf("invalid value `%v` from function", v)
+		}
+		return v, true, nil
+	})
+}
+
+func parseDimension(defaultVal genum.Enum, s, source string) (genum.Enum, bool, error) {
+	v, err := defaultVal.ParseGeneric(s)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid value `%s` from %s: %w", s, source, err)
+	}
+	return v, true, nil
+}
@@ -0,0 +1,262 @@
+package gconfig
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/gconfig/internal"
+	"github.com/drshriveer/gtools/genum"
+)
+
+const resolverTestConfig = `
+name:
+  D1a: a
+  D1b: b
+  D1c: c
+  default: default
+`
+
+func TestBuilder_WithDimensionResolvers(t *testing.T) {
+	dir := t.TempDir()
+	stageFile := filepath.Join(dir, "stage")
+	require.NoError(t, os.WriteFile(stageFile, []byte("D1c\n"), 0o600))
+	invalidFile := filepath.Join(dir, "invalid")
+	require.NoError(t, os.WriteFile(invalidFile, []byte("D9"), 0o600))
+	t.Setenv("RESOLVER_TEST_D1", "D1a")
+
+	host := HostnameFunc(func() (string, error) { return "api-D1b-3.example.com", nil })
+	notFound := DimensionFromFunc(func() (genum.Enum, error) { return nil, nil })
+
+	tests := []struct {
+		description string
+		resolvers   []DimensionResolver
+
+		expected    string
+		expectedErr string
+	}{
+		{
+			description: "no resolvers",
+			expected:    "default",
+		},
+		{
+			description: "env",
+			resolvers:   []DimensionResolver{DimensionFromEnv("RESOLVER_TEST_D1")},
+			expected:    "a",
+		},
+		{
+			description: "file",
+			resolvers:   []DimensionResolver{DimensionFromFile(stageFile)},
+			expected:    "c",
+		},
+		{
+			description: "hostname first subexpression",
+			resolvers:   []DimensionResolver{DimensionFromHostname(regexp.MustCompile(`^api-(\w+)-(\d+)`), host)},
+			expected:    "b",
+		},
+		{
+			description: "hostname named subexpression",
+			resolvers:   []DimensionResolver{DimensionFromHostname(regexp.MustCompile(`^(\w+)-(?P<value>\w+)-`), host)},
+			expected:    "b",
+		},
+		{
+			description: "hostname whole match",
+			resolvers:   []DimensionResolver{DimensionFromHostname(regexp.MustCompile(`D1\w`), host)},
+			expected:    "b",
+		},
+		{
+			description: "func",
+			resolvers: []DimensionResolver{
+				DimensionFromFunc(func() (genum.Enum, error) { return internal.D1c, nil }),
+			},
+			expected: "c",
+		},
+		{
+			description: "precedence: first found wins",
+			resolvers: []DimensionResolver{
+				notFound,
+				DimensionFromEnv("RESOLVER_TEST_MISSING"),
+				DimensionFromFile(filepath.Join(dir, "missing")),
+				DimensionFromHostname(regexp.MustCompile(`^web-(\w+)`), host),
+				DimensionFromFile(stageFile),
+				DimensionFromEnv("RESOLVER_TEST_D1"),
+			},
+			expected: "c",
+		},
+		{
+			description: "all not found",
+			resolvers:   []DimensionResolver{notFound, DimensionFromFile(filepath.Join(dir, "missing"))},
+			expected:    "default",
+		},
+		{
+			description: "invalid value",
+			resolvers:   []DimensionResolver{DimensionFromFile(invalidFile)},
+			expectedErr: "dimension res-d1: invalid value `D9` from file " + invalidFile,
+		},
+		{
+			description: "error",
+			resolvers: []DimensionResolver{
+				DimensionFromFunc(func() (genum.Enum, error) { return nil, errors.New("boom") }),
+				DimensionFromFile(stageFile),
+			},
+			expectedErr: "dimension res-d1: boom",
+		},
+		{
+			description: "hostname error",
+			resolvers: []DimensionResolver{
+				DimensionFromHostname(regexp.MustCompile(`.*`), HostnameFunc(func() (string, error) {
+					return "", errors.New("no hostname")
+				})),
+			},
+			expectedErr: "dimension res-d1: no hostname",
+		},
+		{
+			description: "flags without a flag set",
+			resolvers:   []DimensionResolver{DimensionFromFlags()},
+			expectedErr: "dimension res-d1: DimensionFromFlags requires WithFlagSet or WithPFlags",
+		},
+		{
+			description: "invalid function value",
+			resolvers: []DimensionResolver{
+				DimensionFromFunc(func() (genum.Enum, error) { return internal.DimensionOne(99), nil }),
+				DimensionFromFile(stageFile),
+			},
+			expectedErr: "dimension res-d1: invalid value",
+		},
+		{
+			description: "wrong type",
+			resolvers: []DimensionResolver{
+				DimensionFromFunc(func() (genum.Enum, error) { return internal.D2a, nil }),
+			},
+			expectedErr: "dimension res-d1 value internal.DimensionTwo must be the same type",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			cfg, err := NewBuilder().
+				WithDimensionResolvers("res-d1", internal.D1d, test.resolvers...).
+				FromBytes([]byte(resolverTestConfig))
+			if test.expectedErr != "" {
+				assert.ErrorIs(t, err, ErrFailedParsing)
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, MustGet[string](cfg, "name"))
+		})
+	}
+}
+
+func TestBuilder_WithDimensionResolvers_flags(t *testing.T) {
+	t.Setenv("RESOLVER_FLAGS_D1", "D1a")
+	resolvers := []DimensionResolver{
+		DimensionFromFlags(),
+		DimensionFromEnv("RESOLVER_FLAGS_D1"),
+		DimensionFromHostname(regexp.MustCompile(`^api-(\w+)-`),
+			HostnameFunc(func() (string, error) { return "api-D1b-3", nil })),
+	}
+
+	tests := []struct {
+		description string
+		flagsFirst  bool
+		args        []string
+
+		expected    string
+		expectedErr string
+	}{
+		{description: "flag wins", flagsFirst: true, args: []string{"-flag-d1=D1c"}, expected: "c"},
+		{description: "flag set after the dimension", args: []string{"-flag-d1=D1c"}, expected: "c"},
+		{description: "env when the flag is not set", flagsFirst: true, expected: "a"},
+		{
+			description: "invalid flag",
+			flagsFirst:  true,
+			args:        []string{"-flag-d1=D9"},
+			expectedErr: "invalid value",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			builder := NewBuilder()
+			if test.flagsFirst {
+				builder.WithFlagSet(fs)
+			}
+			builder.WithDimensionResolvers("flag-d1", internal.D1d, resolvers...)
+			if !test.flagsFirst {
+				builder.WithFlagSet(fs)
+			}
+			assert.Nil(t, flag.Lookup("flag-d1"), "the global flag set must not be modified")
+
+			err := fs.Parse(test.args)
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			cfg, err := builder.FromBytes([]byte(resolverTestConfig))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, MustGet[string](cfg, "name"))
+		})
+	}
+
+	// without the env variable the hostname is next.
+	require.NoError(t, os.Unsetenv("RESOLVER_FLAGS_D1"))
+	cfg, err := NewBuilder().
+		WithFlagSet(flag.NewFlagSet("test", flag.ContinueOnError)).
+		WithDimensionResolvers("flag-d1", internal.D1d, resolvers...).
+		FromBytes([]byte(resolverTestConfig))
+	require.NoError(t, err)
+	assert.Equal(t, "b", MustGet[string](cfg, "name"))
+}
+
+func TestBuilder_WithDimensionResolvers_deferred(t *testing.T) {
+	builder := NewBuilder().WithDimensionResolvers("deferred-d1", internal.D1d, DimensionFromEnv("RESOLVER_DEFERRED_D1"))
+	// resolvers are consulted when the configuration is built, not when they are added.
+	t.Setenv("RESOLVER_DEFERRED_D1", "D1b")
+	cfg, err := builder.FromBytes([]byte(resolverTestConfig))
+	require.NoError(t, err)
+	assert.Equal(t, "b", MustGet[string](cfg, "name"))
+
+	// each build resolves its own dimensions; earlier configurations keep theirs.
+	t.Setenv("RESOLVER_DEFERRED_D1", "D1c")
+	next, err := builder.FromBytes([]byte(resolverTestConfig))
+	require.NoError(t, err)
+	assert.Equal(t, "c", MustGet[string](next, "name"))
+	assert.Equal(t, internal.D1c, GetDimension[internal.DimensionOne](next))
+	assert.Equal(t, internal.D1b, GetDimension[internal.DimensionOne](cfg))
+}
+
+func TestBuilder_resolveDimensions_concurrent(t *testing.T) {
+	t.Setenv("RESOLVER_CONCURRENT_D1", "D1b")
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	builder := NewBuilder().
+		WithFlagSet(fs).
+		WithDimension("concurrent-d1", internal.D1d).
+		WithDimensionResolvers("concurrent-d2", internal.D2a, DimensionFromEnv("RESOLVER_CONCURRENT_D2"))
+	require.NoError(t, fs.Parse([]string{"-concurrent-d1=D1c"}))
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cfg, err := builder.FromBytes([]byte(resolverTestConfig))
+			assert.NoError(t, err)
+			assert.Equal(t, "c", MustGet[string](cfg, "name"))
+		}()
+	}
+	wg.Wait()
+	// the builder's own dimensions keep their defaults.
+	for _, d := range builder.dimensions {
+		assert.Equal(t, d.defaultVal, d.get())
+	}
+}
@@ -0,0 +1,131 @@
+package gconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces the values of sensitive keys in dumps.
+const redacted = "[REDACTED]"
+
+// DumpFormat is the output format of Config.Dump.
+type DumpFormat int
+
+const (
+	// DumpYAML writes configuration as yaml.
+	DumpYAML DumpFormat = iota
+	// DumpJSON writes configuration as indented json.
+	DumpJSON
+)
+
+// WithSensitiveKeys marks keys whose values are redacted by Config.Dump, e.g. `clients.redis.password`.
+// A path segment may be a pattern as supported by path.Match, so `*.password` redacts the password
+// of every top-level key. Marking a key redacts everything beneath it.
+func (b *Builder) WithSensitiveKeys(keys ...string) *Builder {
+	b.sensitiveKeys = append(b.sensitiveKeys, keys...)
+	return b
+}
+
+// Dump writes the effective configuration, after dimensions have been reduced and templates
+// and environment overrides resolved, in the format requested.
+// The values of keys marked with Builder.WithSensitiveKeys are redacted.
+// A view (see Sub) dumps only its subtree.
+func (c *Config) Dump(w io.Writer, format DumpFormat) error {
+	var data any = c.state.Load().data
+	var paths []string
+	if c.prefix != "" {
+		paths = strings.Split(c.prefix, ".")
+		data, _ = extract(c.state.Load().data, paths)
+	}
+	data = c.redact(deepCopy(data), paths)
+
+	switch format {
+	case DumpYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(data); err != nil {
+			return ErrConfigFailure.Convert(err)
+		}
+		if err := enc.Close(); err != nil {
+			return ErrConfigFailure.Convert(err)
+		}
+		return nil
+	case DumpJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(jsonCompatible(data)); err != nil {
+			return ErrConfigFailure.Convert(err)
+		}
+		return nil
+	default:
+		return ErrConfigFailure.Msg("unknown dump format %d", format)
+	}
+}
+
+// redact replaces the values of sensitive keys in place.
+func (c *Config) redact(in any, paths []string) any {
+	if len(paths) > 0 && c.isSensitive(paths) {
+		return redacted
+	}
+	switch v := in.(type) {
+	case map[string]any:
+		for k, el := range v {
+			v[k] = c.redact(el, append(paths[:len(paths):len(paths)], k))
+		}
+	case map[any]any:
+		for k, el := range v {
+			v[k] = c.redact(el, append(paths[:len(paths):len(paths)], fmt.Sprint(k)))
+		}
+	case []any:
+		for i, el := range v {
+			v[i] = c.redact(el, paths)
+		}
+	}
+	return in
+}
+
+func (c *Config) isSensitive(paths []string) bool {
+	for _, key := range c.sensitiveKeys {
+		patterns := strings.Split(key, ".")
+		if len(patterns) != len(paths) {
+			continue
+		}
+		matched := true
+		for i, pattern := range patterns {
+			if ok, _ := path.Match(pattern, paths[i]); !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonCompatible converts maps with non-string keys, which json cannot encode, to string keyed maps.
+func jsonCompatible(in any) any {
+	switch v := in.(type) {
+	case map[string]any:
+		for k, el := range v {
+			v[k] = jsonCompatible(el)
+		}
+	case map[any]any:
+		result := make(map[string]any, len(v))
+		for k, el := range v {
+			result[fmt.Sprint(k)] = jsonCompatible(el)
+		}
+		return result
+	case []any:
+		for i, el := range v {
+			v[i] = jsonCompatible(el)
+		}
+	}
+	return in
+}
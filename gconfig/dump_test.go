@@ -0,0 +1,88 @@
+package gconfig_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/gconfig"
+	"github.com/drshriveer/gtools/gconfig/internal"
+)
+
+const dumpTestConfig = `
+clients:
+  redis:
+    address:
+      D1a: prod.redis:4090
+      default: localhost:4090
+    password: "${{env: DUMP_TEST_PASSWORD | hunter2}}"
+  serviceA:
+    password: letmein
+    ports: {1: http, 2: grpc}
+`
+
+func TestConfig_Dump(t *testing.T) {
+	cfg, err := gconfig.NewBuilder().
+		WithDimension("d1", internal.D1a).
+		WithSensitiveKeys("clients.*.password").
+		FromBytes([]byte(dumpTestConfig))
+	require.NoError(t, err)
+
+	tests := []struct {
+		description string
+		format      gconfig.DumpFormat
+		expected    string
+	}{
+		{
+			description: "yaml",
+			format:      gconfig.DumpYAML,
+			expected: `clients:
+  redis:
+    address: prod.redis:4090
+    password: '[REDACTED]'
+  serviceA:
+    password: '[REDACTED]'
+    ports:
+      1: http
+      2: grpc
+`,
+		},
+		{
+			description: "json",
+			format:      gconfig.DumpJSON,
+			expected: `{
+  "clients": {
+    "redis": {
+      "address": "prod.redis:4090",
+      "password": "[REDACTED]"
+    },
+    "serviceA": {
+      "password": "[REDACTED]",
+      "ports": {
+        "1": "http",
+        "2": "grpc"
+      }
+    }
+  }
+}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			require.NoError(t, cfg.Dump(buf, test.format))
+			assert.Equal(t, test.expected, buf.String())
+		})
+	}
+
+	// dumping never modifies the configuration.
+	assert.Equal(t, "hunter2", gconfig.MustGet[string](cfg, "clients.redis.password"))
+	assert.Equal(t, map[int]string{1: "http", 2: "grpc"},
+		gconfig.MustGet[map[int]string](cfg, "clients.serviceA.ports"))
+
+	assert.ErrorIs(t, cfg.Dump(&bytes.Buffer{}, gconfig.DumpFormat(99)), gconfig.ErrConfigFailure)
+}
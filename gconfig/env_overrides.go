@@ -0,0 +1,117 @@
+package gconfig
+
+import (
+	"os"
+	"strings"
+)
+
+const defaultEnvOverrideSeparator = "__"
+
+// envOverrides maps configuration keys to environment variables which, when set,
+// replace the value found in the configuration file.
+type envOverrides struct {
+	prefix    string
+	separator string
+}
+
+// envName converts a configuration key path into the environment variable name
+// that may override it. e.g. with prefix `APP` and separator `__` the key
+// `clients.redis.request-timeout` becomes `APP__CLIENTS__REDIS__REQUEST_TIMEOUT`.
+func (o *envOverrides) envName(paths []string) string {
+	parts := make([]string, 0, len(paths)+1)
+	if o.prefix != "" {
+		parts = append(parts, o.prefix)
+	}
+	for _, p := range paths {
+		parts = append(parts, strings.NewReplacer("-", "_", ".", "_").Replace(p))
+	}
+	return strings.ToUpper(strings.Join(parts, o.separator))
+}
+
+// apply replaces the values of keys of an (already reduced) configuration tree that have an
+// override set in the environment. Overrides are applied to the tree itself, and only there, so a
+// key reads the same value whether it is fetched directly or as part of a parent struct or map.
+// With a prefix, variables that match no key add one, e.g. `APP__NEW__KEY` adds `new.key`.
+// Values are kept as the raw strings they were set to, and converted when they are read.
+// The matching origins (if any) are updated to record the environment variable as the source of the value.
+func (o *envOverrides) apply(m, origins map[string]any) {
+	if o == nil {
+		return
+	}
+	applied := make(map[string]bool)
+	o.applyExisting(m, origins, nil, applied)
+	if o.prefix == "" {
+		return
+	}
+
+	prefix := strings.ToUpper(o.prefix + o.separator)
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		rest, ok := strings.CutPrefix(strings.ToUpper(name), prefix)
+		if !ok || rest == "" || applied[strings.ToUpper(name)] {
+			continue
+		}
+		paths := strings.Split(strings.ToLower(rest), strings.ToLower(o.separator))
+		addOverride(m, origins, paths, value, envOrigin(name))
+	}
+}
+
+// applyExisting replaces the values of keys in the tree, recording the variables it used.
+func (o *envOverrides) applyExisting(m, origins map[string]any, paths []string, applied map[string]bool) {
+	for k, v := range m {
+		keyPaths := append(paths[:len(paths):len(paths)], k)
+		name := o.envName(keyPaths)
+		if override, ok := lookupEnv(name); ok {
+			applied[name] = true
+			m[k] = override
+			if origins != nil {
+				origins[k] = envOrigin(name)
+			}
+			continue
+		}
+		if sub, ok := v.(map[string]any); ok {
+			subOrigins, _ := origins[k].(map[string]any)
+			o.applyExisting(sub, subOrigins, keyPaths, applied)
+		}
+	}
+}
+
+// addOverride adds a key that is not in the configuration, unless it conflicts with one that is.
+func addOverride(m, origins map[string]any, paths []string, value, origin string) {
+	for _, p := range paths[:len(paths)-1] {
+		if p == "" {
+			return
+		}
+		next, ok := m[p]
+		if !ok {
+			next = make(map[string]any)
+			m[p] = next
+		}
+		sub, ok := next.(map[string]any)
+		if !ok {
+			return
+		}
+		m = sub
+		if origins != nil {
+			subOrigins, ok := origins[p].(map[string]any)
+			if !ok {
+				subOrigins = make(map[string]any)
+				origins[p] = subOrigins
+			}
+			origins = subOrigins
+		}
+	}
+	last := paths[len(paths)-1]
+	if _, ok := m[last]; ok || last == "" {
+		return
+	}
+	m[last] = value
+	if origins != nil {
+		origins[last] = origin
+	}
+}
+
+// envOrigin is the origin recorded for a value supplied by an environment variable.
+func envOrigin(name string) string {
+	return "env:" + name
+}
@@ -0,0 +1,118 @@
+package gconfig_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/gconfig"
+	"github.com/drshriveer/gtools/gconfig/internal"
+)
+
+func TestEnvOverrides(t *testing.T) {
+	t.Setenv("APP__SCALARS__NUMBERS__INT", "42")
+	t.Setenv("APP__DIMENSIONS__VALID__V1", "overridden")
+	t.Setenv("APP__STRUCT__STANDARD__DURATION", "10s")
+	t.Setenv("APP__STRUCT__STANDARD__NAME", "overridden name")
+	t.Setenv("APP__NOT__IN__FILE", "[1, 2, 3]")
+	t.Setenv("OTHER__SCALARS__STRINGS", "ignored")
+
+	cfg, err := gconfig.NewBuilder().
+		WithDimension("d1", internal.D1a).
+		WithDimension("d2", internal.D2a).
+		WithEnvOverrides("APP", "").
+		FromFile(testFS, "internal/test.yaml")
+	require.NoError(t, err)
+
+	t.Run("scalar", func(t *testing.T) {
+		assert.Equal(t, 42, gconfig.MustGet[int](cfg, "scalars.numbers.int"))
+		assert.Equal(t, "42", gconfig.MustGet[string](cfg, "scalars.numbers.int"))
+	})
+
+	t.Run("takes precedence over dimensions", func(t *testing.T) {
+		assert.Equal(t, "overridden", gconfig.MustGet[string](cfg, "dimensions.valid.v1"))
+	})
+
+	t.Run("scalar within a struct", func(t *testing.T) {
+		expected := testStruct{
+			Pi:       3.14159,
+			E:        2.71828,
+			Duration: 10 * time.Second,
+			UseReal:  true,
+			Name:     "overridden name",
+		}
+		assert.Equal(t, expected, gconfig.MustGet[testStruct](cfg, "struct.standard"))
+		assert.Equal(t, 10*time.Second, gconfig.MustGet[time.Duration](cfg, "struct.standard.duration"))
+	})
+
+	t.Run("key not in file", func(t *testing.T) {
+		assert.Equal(t, []int{1, 2, 3}, gconfig.MustGet[[]int](cfg, "not.in.file"))
+	})
+
+	t.Run("other prefixes are ignored", func(t *testing.T) {
+		assert.Equal(t, "a.value.here", gconfig.MustGet[string](cfg, "scalars.strings"))
+	})
+
+	t.Run("GetOrDefault", func(t *testing.T) {
+		assert.Equal(t, "overridden name", gconfig.GetOrDefault(cfg, "struct.standard.name", "default"))
+		assert.Equal(t, "default", gconfig.GetOrDefault(cfg, "struct.standard.missing", "default"))
+	})
+}
+
+func TestEnvOverrides_RawValues(t *testing.T) {
+	tests := []struct {
+		description string
+		value       string
+	}{
+		{description: "comment", value: "p@ss #1"},
+		{description: "leading zero", value: "0123"},
+		{description: "exponent", value: "1e3"},
+		{description: "colon", value: "a: b"},
+		{description: "braces", value: "{a: b}"},
+		{description: "empty", value: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			t.Setenv("RAW__STRUCT__STANDARD__NAME", test.value)
+			cfg, err := gconfig.NewBuilder().
+				WithDimension("d1", internal.D1a).
+				WithDimension("d2", internal.D2a).
+				WithEnvOverrides("RAW", "").
+				FromFile(testFS, "internal/test.yaml")
+			require.NoError(t, err)
+
+			assert.Equal(t, test.value, gconfig.MustGet[string](cfg, "struct.standard.name"))
+			assert.Equal(t, test.value, gconfig.MustGet[testStruct](cfg, "struct.standard").Name,
+				"the parent struct sees the same value")
+			assert.Equal(t, test.value, gconfig.MustGet[map[string]any](cfg, "struct.standard")["name"])
+		})
+	}
+
+	t.Run("typed on read", func(t *testing.T) {
+		t.Setenv("RAW__SCALARS__NUMBERS__INT", "0123")
+		cfg, err := gconfig.NewBuilder().
+			WithDimension("d1", internal.D1a).
+			WithDimension("d2", internal.D2a).
+			WithEnvOverrides("RAW", "").
+			FromFile(testFS, "internal/test.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, 123, gconfig.MustGet[int](cfg, "scalars.numbers.int"))
+		assert.Equal(t, "0123", gconfig.MustGet[string](cfg, "scalars.numbers.int"))
+	})
+}
+
+func TestEnvOverrides_CustomSeparator(t *testing.T) {
+	t.Setenv("SVC_STRUCT_NESTEDDIMENSION_USEREAL", "false")
+
+	cfg, err := gconfig.NewBuilder().
+		WithDimension("d1", internal.D1a).
+		WithDimension("d2", internal.D2a).
+		WithEnvOverrides("SVC", "_").
+		FromFile(testFS, "internal/test.yaml")
+	require.NoError(t, err)
+
+	assert.False(t, gconfig.MustGet[bool](cfg, "struct.nestedDimension.useReal"))
+	assert.False(t, gconfig.MustGet[testStruct](cfg, "struct.nestedDimension").UseReal)
+}
@@ -0,0 +1,89 @@
+package gconfig
+
+import (
+	"flag"
+	"reflect"
+
+	"github.com/spf13/pflag"
+
+	"github.com/drshriveer/gtools/genum"
+)
+
+// flagSet is a set of flags dimensions are registered with and read from; see Builder.WithFlagSet.
+type flagSet interface {
+	// register registers d as a flag, unless a flag of the same name exists already.
+	register(d *dimension)
+	// lookup returns the value of the flag name if it was set.
+	lookup(name string) (string, bool)
+}
+
+type stdFlagSet struct {
+	*flag.FlagSet
+}
+
+func (fs stdFlagSet) register(d *dimension) {
+	if fs.Lookup(d.flagName) == nil {
+		fs.Var(&dimensionFlag{defaultVal: d.defaultVal}, d.flagName, d.usage())
+	}
+}
+
+func (fs stdFlagSet) lookup(name string) (value string, ok bool) {
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			value, ok = f.Value.String(), true
+		}
+	})
+	return value, ok
+}
+
+type pFlagSet struct {
+	*pflag.FlagSet
+}
+
+func (fs pFlagSet) register(d *dimension) {
+	if fs.Lookup(d.flagName) == nil {
+		fs.Var(&dimensionFlag{defaultVal: d.defaultVal}, d.flagName, d.usage())
+	}
+}
+
+func (fs pFlagSet) lookup(name string) (string, bool) {
+	f := fs.Lookup(name)
+	if f == nil || !f.Changed {
+		return "", false
+	}
+	return f.Value.String(), true
+}
+
+// dimensionFlag is the value of a dimension's flag. It only validates and holds what the flag was
+// set to; dimensions read it through the flag set when a configuration is built.
+type dimensionFlag struct {
+	defaultVal genum.Enum
+	value      genum.Enum
+}
+
+// String implements flag.Value and pflag.Value.
+func (f *dimensionFlag) String() string {
+	switch {
+	case f == nil || f.defaultVal == nil:
+		return ""
+	case f.value == nil:
+		return f.defaultVal.String()
+	default:
+		return f.value.String()
+	}
+}
+
+// Set implements flag.Value and pflag.Value.
+func (f *dimensionFlag) Set(s string) error {
+	v, err := f.defaultVal.ParseGeneric(s)
+	if err != nil {
+		return err
+	}
+	f.value = v
+	return nil
+}
+
+// Type implements pflag.Value.
+func (f *dimensionFlag) Type() string {
+	return reflect.TypeOf(f.defaultVal).Name()
+}
@@ -0,0 +1,144 @@
+package gconfig_test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/gconfig"
+	"github.com/drshriveer/gtools/gconfig/internal"
+	"github.com/drshriveer/gtools/genum"
+)
+
+func TestBuilder_WithFlagSet(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	builder := gconfig.NewBuilder().
+		WithFlagSet(fs).
+		WithDimension("fs-d1", internal.D1a).
+		WithDimension("fs-d2", internal.D2a)
+	assert.Nil(t, flag.Lookup("fs-d1"), "the global flag set must not be modified")
+	assert.Contains(t, fs.Lookup("fs-d1").Usage, "configuration dimension valid options")
+
+	require.NoError(t, fs.Parse([]string{"-fs-d1=D1c", "-fs-d2", "D2e"}))
+	cfg, err := builder.FromFile(testFS, "internal/test.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, internal.D1c, gconfig.GetDimension[internal.DimensionOne](cfg))
+	assert.Equal(t, internal.D2e, gconfig.GetDimension[internal.DimensionTwo](cfg))
+	assert.Equal(t, "v1:default", gconfig.MustGet[string](cfg, "dimensions.valid.v1"))
+
+	assert.Error(t, fs.Parse([]string{"-fs-d1=invalid"}))
+}
+
+func TestBuilder_WithPFlags(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	builder := gconfig.NewBuilder().
+		WithPFlags(fs).
+		WithDimension("pf-d1", internal.D1a)
+	assert.Nil(t, flag.Lookup("pf-d1"), "the global flag set must not be modified")
+	assert.Equal(t, "DimensionOne", fs.Lookup("pf-d1").Value.Type())
+
+	require.NoError(t, fs.Parse([]string{"--pf-d1", "D1b"}))
+	cfg, err := builder.FromFile(testFS, "internal/test.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, internal.D1b, gconfig.GetDimension[internal.DimensionOne](cfg))
+	assert.Equal(t, "v1:D1b", gconfig.MustGet[string](cfg, "dimensions.valid.v1"))
+}
+
+func TestBuilder_WithFlagSet_order(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	builder := gconfig.NewBuilder().
+		WithDimension("fs-order-d1", internal.D1a).
+		WithFlagSet(fs).
+		WithDimension("fs-order-d2", internal.D2a)
+	require.NotNil(t, fs.Lookup("fs-order-d1"), "dimensions added before the flag set must be bound")
+	require.NotNil(t, fs.Lookup("fs-order-d2"))
+	assert.Nil(t, flag.Lookup("fs-order-d1"), "the global flag set must not be modified")
+
+	require.NoError(t, fs.Parse([]string{"-fs-order-d1=D1b", "-fs-order-d2=D2e"}))
+	cfg, err := builder.FromFile(testFS, "internal/test.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, internal.D1b, gconfig.GetDimension[internal.DimensionOne](cfg))
+	assert.Equal(t, internal.D2e, gconfig.GetDimension[internal.DimensionTwo](cfg))
+}
+
+func TestBuilder_WithFlagSet_existingFlag(t *testing.T) {
+	tests := []struct {
+		description string
+		args        []string
+
+		expected    internal.DimensionOne
+		expectedErr string
+	}{
+		{description: "set", args: []string{"-fs-existing=D1b"}, expected: internal.D1b},
+		{description: "not set", expected: internal.D1a},
+		{
+			description: "invalid",
+			args:        []string{"-fs-existing=D9"},
+			expectedErr: "dimension fs-existing: invalid value `D9` from flag fs-existing",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.String("fs-existing", "", "a flag registered by the application")
+			builder := gconfig.NewBuilder().
+				WithFlagSet(fs).
+				WithDimension("fs-existing", internal.D1a)
+			assert.Equal(t, "a flag registered by the application", fs.Lookup("fs-existing").Usage)
+
+			require.NoError(t, fs.Parse(test.args))
+			cfg, err := builder.FromFile(testFS, "internal/test.yaml")
+			if test.expectedErr != "" {
+				assert.ErrorIs(t, err, gconfig.ErrFailedParsing)
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, gconfig.GetDimension[internal.DimensionOne](cfg))
+		})
+	}
+}
+
+func TestBuilder_WithPFlags_existingFlag(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.String("pf-existing", "", "a flag registered by the application")
+	builder := gconfig.NewBuilder().
+		WithDimension("pf-existing", internal.D1a).
+		WithPFlags(fs)
+	assert.Nil(t, flag.Lookup("pf-existing"), "the global flag set must not be modified")
+
+	require.NoError(t, fs.Parse([]string{"--pf-existing", "D1c"}))
+	cfg, err := builder.FromFile(testFS, "internal/test.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, internal.D1c, gconfig.GetDimension[internal.DimensionOne](cfg))
+}
+
+func TestBuilder_WithDimensionValue(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		description string
+		explicit    genum.Enum
+		expected    string
+	}{
+		{description: "explicit D1b", explicit: internal.D1b, expected: "v1:D1b"},
+		{description: "default", expected: "v1:default"},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+			cfg, err := gconfig.NewBuilder().
+				WithDimensionValue("vd1", internal.D1c, test.explicit).
+				FromFile(testFS, "internal/test.yaml")
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, gconfig.MustGet[string](cfg, "dimensions.valid.v1"))
+			assert.Nil(t, flag.Lookup("vd1"))
+		})
+	}
+
+	assert.Panics(t, func() {
+		gconfig.NewBuilder().WithDimensionValue("vd1", internal.D1c, internal.D2a)
+	})
+}
@@ -5,10 +5,12 @@ go 1.23.0
 toolchain go1.23.7
 
 require (
+	github.com/BurntSushi/toml v1.5.0
 	github.com/drshriveer/gtools/genum v0.0.0-20251103190437-0d41f34ed835
 	github.com/drshriveer/gtools/gerror v0.0.0-20240118184715-48af8963ff63
 	github.com/drshriveer/gtools/set v0.0.0-20251103190437-0d41f34ed835
 	github.com/puzpuzpuz/xsync/v3 v3.5.1
+	github.com/spf13/pflag v1.0.10
 	github.com/stretchr/testify v1.9.0
 	gopkg.in/yaml.v3 v3.0.1
 )
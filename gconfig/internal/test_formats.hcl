@@ -0,0 +1,20 @@
+/* the same configuration as test_formats.yaml */
+service {
+  name    = "api"
+  timeout = "5s"
+  ratio   = 0.5
+  enabled = true
+  hosts   = ["a.host", "b.host"]
+  url     = "http://${{env: FORMAT_TEST_HOST | localhost}}:80"
+
+  // blocks with the same name merge.
+  port {
+    D1a = 8080
+  }
+}
+
+service {
+  port {
+    default = 9090 # the fallback port
+  }
+}
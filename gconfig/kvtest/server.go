@@ -0,0 +1,123 @@
+// Package kvtest provides an in-memory key-value store served over HTTP with a consul-style API.
+// It stands in for a real configuration store when testing configuration loaded with
+// gconfig.HTTPSource, entirely offline.
+//
+//	kv := kvtest.NewServer()
+//	defer kv.Close()
+//	kv.Set("app/config.yaml", []byte("timeout: 5s"))
+//	cfg, err := gconfig.NewBuilder().WatchSource(ctx, kv.Source("app/config.yaml"))
+package kvtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/drshriveer/gtools/gconfig"
+)
+
+// kvPath is the path values are served under, as in consul's key-value API.
+const kvPath = "/v1/kv/"
+
+// Server is an in-memory key-value store served by an httptest.Server.
+// `GET /v1/kv/<key>` returns the raw value of a key along with an ETag (and X-Consul-Index) header
+// holding the index of its last modification; a request with a matching If-None-Match header
+// receives `304 Not Modified`.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	values   map[string]entry
+	index    uint64
+	failing  bool
+	requests int
+}
+
+type entry struct {
+	value []byte
+	index uint64
+}
+
+// NewServer starts a new, empty, Server. Call Close when done.
+func NewServer() *Server {
+	s := &Server{values: make(map[string]entry)}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Set sets the value of a key.
+func (s *Server) Set(key string, value []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.index++
+	s.values[key] = entry{value: append([]byte(nil), value...), index: s.index}
+}
+
+// Delete removes a key.
+func (s *Server) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.values, key)
+}
+
+// SetFailing makes every request fail with `503 Service Unavailable` until it is unset.
+func (s *Server) SetFailing(failing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failing = failing
+}
+
+// Requests returns the number of requests the server has received.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// KeyURL returns the URL of the raw value of a key.
+func (s *Server) KeyURL(key string) string {
+	return s.URL + kvPath + key + "?raw"
+}
+
+// Source returns a gconfig.HTTPSource for a key, named after it.
+func (s *Server) Source(key string) *gconfig.HTTPSource {
+	return &gconfig.HTTPSource{
+		SourceName: key,
+		URL:        s.KeyURL(key),
+		Client:     s.Client(),
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+
+	key, ok := strings.CutPrefix(r.URL.Path, kvPath)
+	switch {
+	case s.failing:
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	case !ok || r.Method != http.MethodGet:
+		http.NotFound(w, r)
+		return
+	}
+	e, ok := s.values[key]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	etag := strconv.Quote(strconv.FormatUint(e.index, 10))
+	w.Header().Set("ETag", etag)
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(e.index, 10))
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = w.Write(e.value)
+}
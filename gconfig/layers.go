@@ -0,0 +1,190 @@
+package gconfig
+
+import (
+	"io/fs"
+)
+
+// ListMergeStrategy determines how lists are merged when the same key is defined by
+// multiple layers.
+type ListMergeStrategy int
+
+const (
+	// ListReplace replaces a list from an earlier layer with the list from a later one.
+	ListReplace ListMergeStrategy = iota
+	// ListAppend appends the items of a list from a later layer to the list from an earlier one.
+	ListAppend
+)
+
+// WithListMerge sets how lists defined in more than one layer are merged by FromLayers.
+// Defaults to ListReplace.
+func (b *Builder) WithListMerge(strategy ListMergeStrategy) *Builder {
+	b.listMerge = strategy
+	return b
+}
+
+// Layer is a single, named source of configuration to be merged by Builder.FromLayers.
+// The name is reported by Config.Origins for the values the layer supplies.
+type Layer struct {
+	Name string
+	load func(d decoders) (map[string]any, error)
+}
+
+// FileLayer is a layer read from a configuration file, decoded by the decoder registered for
+// its extension (see Builder.WithDecoder). The layer is named after the file.
+func FileLayer(fileSystem fs.FS, filename string) Layer {
+	return Layer{
+		Name: filename,
+		load: func(d decoders) (map[string]any, error) {
+			bytes, err := readFile(fileSystem, filename)
+			if err != nil {
+				return nil, err
+			}
+			return decode(d.forName(filename), bytes)
+		},
+	}
+}
+
+// BytesLayer is a layer parsed from configuration file bytes, decoded by the decoder registered
+// for the extension of its name.
+func BytesLayer(name string, bytes []byte) Layer {
+	return Layer{
+		Name: name,
+		load: func(d decoders) (map[string]any, error) {
+			return decode(d.forName(name), bytes)
+		},
+	}
+}
+
+// DecoderLayer is a layer parsed from configuration file bytes by an explicit decoder.
+func DecoderLayer(name string, bytes []byte, decoder Decoder) Layer {
+	return Layer{
+		Name: name,
+		load: func(decoders) (map[string]any, error) {
+			return decode(decoder, bytes)
+		},
+	}
+}
+
+// MapLayer is a layer of in-memory configuration. It is structured exactly like a parsed
+// configuration file: nested keys are nested map[string]any values and lists are []any.
+// The map is copied, never modified.
+func MapLayer(name string, data map[string]any) Layer {
+	return Layer{
+		Name: name,
+		load: func(decoders) (map[string]any, error) {
+			return deepCopy(data).(map[string]any), nil
+		},
+	}
+}
+
+func layerNames(layers []Layer) []string {
+	result := make([]string, len(layers))
+	for i, l := range layers {
+		result[i] = l.Name
+	}
+	return result
+}
+
+// mergeLayers loads and merges all layers in order. Along with the merged data it returns
+// an origins tree of the same shape where every leaf is the name of the layer that supplied it.
+func mergeLayers(layers []Layer, d decoders, listMerge ListMergeStrategy) (data, origins map[string]any, err error) {
+	data = make(map[string]any)
+	origins = make(map[string]any)
+	for _, layer := range layers {
+		layerData, err := layer.load(d)
+		if err != nil {
+			return nil, nil, err
+		}
+		mergeMaps(data, origins, layerData, layer.Name, listMerge)
+	}
+	return data, origins, nil
+}
+
+func mergeMaps(dst, dstOrigins, src map[string]any, layer string, listMerge ListMergeStrategy) {
+	for k, v := range src {
+		switch srcV := v.(type) {
+		case map[string]any:
+			dstV, ok := dst[k].(map[string]any)
+			dstO, oOK := dstOrigins[k].(map[string]any)
+			if !ok || !oOK {
+				dstV, dstO = make(map[string]any, len(srcV)), make(map[string]any, len(srcV))
+				dst[k], dstOrigins[k] = dstV, dstO
+			}
+			mergeMaps(dstV, dstO, srcV, layer, listMerge)
+		case []any:
+			dstV, ok := dst[k].([]any)
+			dstO, oOK := dstOrigins[k].([]any)
+			if listMerge != ListAppend || !ok || !oOK {
+				dstV, dstO = nil, nil
+			}
+			for _, el := range srcV {
+				dstV = append(dstV, el)
+				dstO = append(dstO, originsOf(el, layer))
+			}
+			dst[k], dstOrigins[k] = dstV, dstO
+		default:
+			dst[k], dstOrigins[k] = v, layer
+		}
+	}
+}
+
+// originsOf builds an origins tree for a value supplied entirely by one layer.
+func originsOf(v any, layer string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for k, el := range v {
+			result[k] = originsOf(el, layer)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, el := range v {
+			result[i] = originsOf(el, layer)
+		}
+		return result
+	default:
+		return layer
+	}
+}
+
+// collectOrigins returns the set of layer names found in an origins tree.
+func collectOrigins(v any, found map[string]bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		for _, el := range v {
+			collectOrigins(el, found)
+		}
+	case []any:
+		for _, el := range v {
+			collectOrigins(el, found)
+		}
+	case string:
+		found[v] = true
+	}
+}
+
+func deepCopy(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for k, el := range v {
+			result[k] = deepCopy(el)
+		}
+		return result
+	case map[any]any:
+		result := make(map[any]any, len(v))
+		for k, el := range v {
+			result[k] = deepCopy(el)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, el := range v {
+			result[i] = deepCopy(el)
+		}
+		return result
+	default:
+		return v
+	}
+}
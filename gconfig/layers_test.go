@@ -0,0 +1,145 @@
+package gconfig_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/gconfig"
+	"github.com/drshriveer/gtools/gconfig/internal"
+)
+
+func TestFromLayers(t *testing.T) {
+	layers := []gconfig.Layer{
+		gconfig.FileLayer(testFS, "internal/test_layer_base.yaml"),
+		gconfig.FileLayer(testFS, "internal/test_layer_overlay.yaml"),
+		gconfig.MapLayer("local", map[string]any{
+			"service": map[string]any{
+				"tags": map[string]any{"tier": 3},
+			},
+			"local": map[string]any{"only": true},
+		}),
+	}
+
+	tests := []struct {
+		description string
+		d1          internal.DimensionOne
+		listMerge   gconfig.ListMergeStrategy
+
+		expectedTimeout time.Duration
+		expectedHosts   []string
+	}{
+		{
+			description:     "dimension variation from overlay",
+			d1:              internal.D1a,
+			expectedTimeout: 5 * time.Second,
+			expectedHosts:   []string{"c.host"},
+		},
+		{
+			description:     "dimension variation from base",
+			d1:              internal.D1b,
+			expectedTimeout: 2 * time.Second,
+			expectedHosts:   []string{"c.host"},
+		},
+		{
+			description:     "default from base",
+			d1:              internal.D1c,
+			expectedTimeout: time.Second,
+			expectedHosts:   []string{"c.host"},
+		},
+		{
+			description:     "lists appended",
+			d1:              internal.D1c,
+			listMerge:       gconfig.ListAppend,
+			expectedTimeout: time.Second,
+			expectedHosts:   []string{"a.host", "b.host", "c.host"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			cfg, err := gconfig.NewBuilder().
+				WithDimension("d1", test.d1).
+				WithListMerge(test.listMerge).
+				FromLayers(layers...)
+			require.NoError(t, err)
+
+			assert.Equal(t, "base-service", gconfig.MustGet[string](cfg, "service.name"))
+			assert.Equal(t, test.expectedTimeout, gconfig.MustGet[time.Duration](cfg, "service.timeout"))
+			assert.Equal(t, test.expectedHosts, gconfig.MustGet[[]string](cfg, "service.hosts"))
+			assert.Equal(t,
+				map[string]string{"team": "overlay", "tier": "3", "owner": "someone"},
+				gconfig.MustGet[map[string]string](cfg, "service.tags"))
+			assert.True(t, gconfig.MustGet[bool](cfg, "local.only"))
+		})
+	}
+}
+
+func TestConfig_Origins(t *testing.T) {
+	t.Setenv("APP__SERVICE__NAME", "from-env")
+
+	cfg, err := gconfig.NewBuilder().
+		WithDimension("d1", internal.D1a).
+		WithListMerge(gconfig.ListAppend).
+		WithEnvOverrides("APP", "").
+		FromLayers(
+			gconfig.FileLayer(testFS, "internal/test_layer_base.yaml"),
+			gconfig.FileLayer(testFS, "internal/test_layer_overlay.yaml"),
+			gconfig.MapLayer("local", map[string]any{
+				"service": map[string]any{"tags": map[string]any{"tier": 3}},
+			}),
+		)
+	require.NoError(t, err)
+
+	tests := []struct {
+		key      string
+		expected []string
+	}{
+		{key: "service.timeout", expected: []string{"internal/test_layer_overlay.yaml"}},
+		{key: "service.tags.team", expected: []string{"internal/test_layer_overlay.yaml"}},
+		{key: "service.tags.tier", expected: []string{"local"}},
+		{
+			key:      "service.tags",
+			expected: []string{"internal/test_layer_overlay.yaml", "local"},
+		},
+		{
+			key:      "service.hosts",
+			expected: []string{"internal/test_layer_base.yaml", "internal/test_layer_overlay.yaml"},
+		},
+		{
+			key: "service",
+			expected: []string{
+				"internal/test_layer_base.yaml",
+				"internal/test_layer_overlay.yaml",
+				"local",
+				"env:APP__SERVICE__NAME",
+			},
+		},
+		{key: "service.name", expected: []string{"env:APP__SERVICE__NAME"}},
+	}
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			origins, ok := cfg.Origins(test.key)
+			assert.True(t, ok)
+			assert.Equal(t, test.expected, origins)
+		})
+	}
+
+	_, ok := cfg.Origins("service.missing")
+	assert.False(t, ok)
+}
+
+func TestFromLayers_Errors(t *testing.T) {
+	_, err := gconfig.NewBuilder().FromLayers(
+		gconfig.FileLayer(testFS, "internal/test_layer_base.yaml"),
+		gconfig.FileLayer(testFS, "internal/does_not_exist.yaml"),
+	)
+	assert.ErrorIs(t, err, gconfig.ErrFailedParsing)
+
+	_, err = gconfig.NewBuilder().FromLayers(
+		gconfig.BytesLayer("broken", []byte("not: [valid")),
+	)
+	assert.ErrorIs(t, err, gconfig.ErrFailedParsing)
+}
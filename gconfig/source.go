@@ -0,0 +1,205 @@
+package gconfig
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	defaultHTTPSourceTimeout = 10 * time.Second
+	// fallbackVersionPrefix marks the version of contents read from a fallback cache file.
+	fallbackVersionPrefix = "fallback:"
+)
+
+// Source is a source of configuration file contents that may change over time, such as a file or
+// a key in a key-value store. Builder.FromSource reads a source once and Builder.WatchSource polls it.
+type Source interface {
+	// Name identifies the source in errors and Config.Origins; its extension selects the decoder
+	// (see Builder.WithDecoder).
+	Name() string
+
+	// Fetch returns the current contents of the source along with an opaque version of them,
+	// e.g. an ETag or a hash. version is the version returned by the previous successful fetch
+	// (empty on the first); if the contents have not changed since, Fetch may return changed=false
+	// and no contents.
+	Fetch(ctx context.Context, version string) (contents []byte, newVersion string, changed bool, err error)
+}
+
+// FromSource fetches configuration from a source once and parses a Config from it.
+func (b *Builder) FromSource(ctx context.Context, src Source) (*Config, error) {
+	contents, _, _, err := src.Fetch(ctx, "")
+	if err != nil {
+		return nil, ErrFailedParsing.Convert(err)
+	}
+	return b.FromLayers(BytesLayer(src.Name(), contents))
+}
+
+// FileSource is a Source read from a file. Its version is a hash of the file's contents.
+func FileSource(fileSystem fs.FS, filename string) Source {
+	return &fileSource{fileSystem: fileSystem, filename: filename}
+}
+
+type fileSource struct {
+	fileSystem fs.FS
+	filename   string
+}
+
+func (s *fileSource) Name() string {
+	return s.filename
+}
+
+func (s *fileSource) Fetch(_ context.Context, version string) ([]byte, string, bool, error) {
+	contents, err := readFile(s.fileSystem, s.filename)
+	if err != nil {
+		return nil, "", false, err
+	}
+	newVersion := contentVersion(contents)
+	return contents, newVersion, newVersion != version, nil
+}
+
+// HTTPSource is a Source fetched with HTTP GET requests, e.g. from the key-value API of consul
+// (`http://consul:8500/v1/kv/app/config.yaml?raw`) or any other server that returns a raw value.
+// The ETag response header is used to detect changes: it is sent back in If-None-Match and a
+// `304 Not Modified` response means the configuration is unchanged. Responses without an ETag
+// are versioned by a hash of their body.
+type HTTPSource struct {
+	// SourceName names the source; it defaults to the last element of the URL's path.
+	SourceName string
+	// URL to fetch.
+	URL string
+	// Header is added to every request, e.g. for authentication tokens.
+	Header http.Header
+	// Client makes requests; defaults to a client with a 10 second timeout.
+	Client *http.Client
+}
+
+// Name implements Source.
+func (s *HTTPSource) Name() string {
+	if s.SourceName != "" {
+		return s.SourceName
+	}
+	req, err := http.NewRequest(http.MethodGet, s.URL, nil)
+	if err != nil {
+		return s.URL
+	}
+	return filepath.Base(req.URL.Path)
+}
+
+// Fetch implements Source.
+func (s *HTTPSource) Fetch(ctx context.Context, version string) ([]byte, string, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, "", false, ErrFailedParsing.Convert(err)
+	}
+	for k, values := range s.Header {
+		for _, v := range values {
+			req.Header.Add(k, v)
+		}
+	}
+	if version != "" {
+		req.Header.Set("If-None-Match", version)
+	}
+
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: defaultHTTPSourceTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", false, ErrFailedParsing.Convert(err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		return nil, version, false, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, "", false, ErrFailedParsing.Msg("fetching %s: unexpected status %s", s.URL, resp.Status)
+	}
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", false, ErrFailedParsing.Convert(err)
+	}
+	newVersion := resp.Header.Get("ETag")
+	if newVersion == "" {
+		newVersion = contentVersion(contents)
+	}
+	return contents, newVersion, newVersion != version, nil
+}
+
+// FallbackSource wraps a source, typically a remote one, with a local cache file.
+// Every successful fetch is written to cacheFile; if the first fetch fails the contents of
+// cacheFile are used instead, so a service can start while its configuration store is down.
+// Failures after the first fetch are reported as usual, keeping the last good configuration.
+// Failing to write cacheFile does not fail the fetch; it is logged instead.
+func FallbackSource(src Source, cacheFile string) Source {
+	return &fallbackSource{Source: src, cacheFile: cacheFile}
+}
+
+type fallbackSource struct {
+	Source
+	cacheFile string
+}
+
+func (s *fallbackSource) Fetch(ctx context.Context, version string) ([]byte, string, bool, error) {
+	sourceVersion := version
+	if strings.HasPrefix(version, fallbackVersionPrefix) {
+		// fetch in full once the source has recovered.
+		sourceVersion = ""
+	}
+	contents, newVersion, changed, err := s.Source.Fetch(ctx, sourceVersion)
+	switch {
+	case err == nil && (changed || sourceVersion != version):
+		if writeErr := writeFileAtomically(s.cacheFile, contents); writeErr != nil {
+			log.Printf("[WARN] - failed to write fallback %s: %+v", s.cacheFile, writeErr)
+		}
+		return contents, newVersion, true, nil
+	case err == nil:
+		return nil, newVersion, false, nil
+	case version != "":
+		return nil, "", false, err
+	}
+
+	//nolint:gosec // G304 the cache file is chosen by the caller.
+	cached, readErr := os.ReadFile(s.cacheFile)
+	if readErr != nil {
+		return nil, "", false, ErrFailedParsing.Convert(errors.Join(err, readErr))
+	}
+	return cached, fallbackVersionPrefix + contentVersion(cached), true, nil
+}
+
+// writeFileAtomically writes a file via a temporary file so readers never see partial contents.
+func writeFileAtomically(filename string, contents []byte) error {
+	dir := filepath.Dir(filename)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(filename)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(contents); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
+
+func contentVersion(contents []byte) string {
+	sum := sha256.Sum256(contents)
+	return hex.EncodeToString(sum[:])
+}
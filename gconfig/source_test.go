@@ -0,0 +1,156 @@
+package gconfig_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/gconfig"
+	"github.com/drshriveer/gtools/gconfig/kvtest"
+)
+
+func TestBuilder_FromSource(t *testing.T) {
+	kv := kvtest.NewServer()
+	t.Cleanup(kv.Close)
+	kv.Set("app/config.json", []byte(`{"server": {"timeout": "5s"}}`))
+
+	cfg, err := gconfig.NewBuilder().FromSource(context.Background(), kv.Source("app/config.json"))
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, gconfig.MustGet[time.Duration](cfg, "server.timeout"))
+	origins, _ := cfg.Origins("server.timeout")
+	assert.Equal(t, []string{"app/config.json"}, origins)
+
+	source := &gconfig.HTTPSource{URL: kv.KeyURL("app/config.json"), Client: kv.Client()}
+	assert.Equal(t, "config.json", source.Name(), "named after the url's path by default")
+
+	_, err = gconfig.NewBuilder().FromSource(context.Background(), kv.Source("app/missing.yaml"))
+	assert.ErrorIs(t, err, gconfig.ErrFailedParsing)
+	assert.ErrorContains(t, err, "404 Not Found")
+}
+
+func TestBuilder_WatchSource(t *testing.T) {
+	kv := kvtest.NewServer()
+	t.Cleanup(kv.Close)
+	kv.Set("app/config.yaml", []byte("timeout: 1s"))
+
+	var errMu sync.Mutex
+	var reloadErrs []error
+	cfg, err := gconfig.NewBuilder().
+		WithReloadInterval(5*time.Millisecond).
+		WithReloadErrorHandler(func(err error) {
+			errMu.Lock()
+			defer errMu.Unlock()
+			reloadErrs = append(reloadErrs, err)
+		}).
+		WatchSource(context.Background(), kv.Source("app/config.yaml"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, cfg.Close()) })
+
+	changes := make(chan time.Duration, 10)
+	gconfig.Subscribe(cfg, "timeout", func(_, newValue time.Duration) {
+		changes <- newValue
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		requests := kv.Requests()
+		assert.Eventually(t, func() bool { return kv.Requests() > requests+2 }, time.Second, time.Millisecond)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("reloads on change", func(t *testing.T) {
+		kv.Set("app/config.yaml", []byte("timeout: 2s"))
+		select {
+		case change := <-changes:
+			assert.Equal(t, 2*time.Second, change)
+		case <-time.After(time.Second):
+			require.Fail(t, "timed out waiting for reload")
+		}
+	})
+
+	t.Run("keeps last good config on failure", func(t *testing.T) {
+		kv.SetFailing(true)
+		assert.Eventually(t, func() bool {
+			errMu.Lock()
+			defer errMu.Unlock()
+			return len(reloadErrs) > 0
+		}, time.Second, 5*time.Millisecond)
+		errMu.Lock()
+		assert.ErrorIs(t, reloadErrs[0], gconfig.ErrFailedParsing)
+		errMu.Unlock()
+		assert.Equal(t, 2*time.Second, gconfig.MustGet[time.Duration](cfg, "timeout"))
+	})
+
+	t.Run("recovers", func(t *testing.T) {
+		kv.Set("app/config.yaml", []byte("timeout: 3s"))
+		kv.SetFailing(false)
+		select {
+		case change := <-changes:
+			assert.Equal(t, 3*time.Second, change)
+		case <-time.After(time.Second):
+			require.Fail(t, "timed out waiting for reload")
+		}
+	})
+}
+
+func TestFallbackSource(t *testing.T) {
+	kv := kvtest.NewServer()
+	t.Cleanup(kv.Close)
+	kv.Set("app/config.yaml", []byte("timeout: 1s"))
+	cacheFile := filepath.Join(t.TempDir(), "cache", "config.yaml")
+
+	// a successful fetch populates the cache.
+	cfg, err := gconfig.NewBuilder().
+		FromSource(context.Background(), gconfig.FallbackSource(kv.Source("app/config.yaml"), cacheFile))
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, gconfig.MustGet[time.Duration](cfg, "timeout"))
+	cached, err := os.ReadFile(cacheFile)
+	require.NoError(t, err)
+	assert.Equal(t, "timeout: 1s", string(cached))
+
+	// the cache is used when the first fetch fails.
+	kv.SetFailing(true)
+	var reloadErrs atomic.Int32
+	cfg, err = gconfig.NewBuilder().
+		WithReloadInterval(5*time.Millisecond).
+		WithReloadErrorHandler(func(error) { reloadErrs.Add(1) }).
+		WatchSource(context.Background(), gconfig.FallbackSource(kv.Source("app/config.yaml"), cacheFile))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, cfg.Close()) })
+	assert.Equal(t, time.Second, gconfig.MustGet[time.Duration](cfg, "timeout"))
+
+	// the outage is reported once rather than on every reload.
+	requests := kv.Requests()
+	assert.Eventually(t, func() bool { return kv.Requests() > requests+3 }, time.Second, time.Millisecond)
+	assert.Equal(t, int32(1), reloadErrs.Load())
+
+	// the source's contents are fetched in full once it recovers.
+	kv.Set("app/config.yaml", []byte("timeout: 2s"))
+	kv.SetFailing(false)
+	assert.Eventually(t, func() bool {
+		return gconfig.MustGet[time.Duration](cfg, "timeout") == 2*time.Second
+	}, time.Second, 5*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		cached, err := os.ReadFile(cacheFile)
+		return err == nil && string(cached) == "timeout: 2s"
+	}, time.Second, 5*time.Millisecond)
+
+	// failing to write the cache does not fail the fetch.
+	notADir := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(notADir, nil, 0o600))
+	cfg, err = gconfig.NewBuilder().FromSource(context.Background(),
+		gconfig.FallbackSource(kv.Source("app/config.yaml"), filepath.Join(notADir, "config.yaml")))
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, gconfig.MustGet[time.Duration](cfg, "timeout"))
+
+	// without a cache, failures are reported.
+	_, err = gconfig.NewBuilder().FromSource(context.Background(),
+		gconfig.FallbackSource(kv.Source("app/missing.yaml"), filepath.Join(t.TempDir(), "missing.yaml")))
+	assert.ErrorIs(t, err, gconfig.ErrFailedParsing)
+}
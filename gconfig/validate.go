@@ -0,0 +1,461 @@
+package gconfig
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/drshriveer/gtools/gerror"
+)
+
+// ErrValidationFailed is returned when a configuration does not meet the expectations
+// registered with Builder.WithExpectations.
+var ErrValidationFailed = gerror.FactoryOf(&gerror.GError{
+	Name:    "ErrValidationFailed",
+	Message: "configuration failed validation",
+})
+
+// WithExpectations registers keys the configuration is expected to contain.
+// When a Config is built every expectation is checked against every combination of
+// dimension values (not only the current one), and all violations are returned together
+// in a single ErrValidationFailed error.
+//
+// Values that are templates which cannot be resolved in the current environment are only
+// checked for presence, as they may resolve in the environment they are meant for.
+func (b *Builder) WithExpectations(expectations ...Expectation) *Builder {
+	b.expectations = append(b.expectations, expectations...)
+	return b
+}
+
+// Expectation describes a key a configuration is expected to have, the type its value must
+// resolve to, and any additional rules it must satisfy.
+type Expectation struct {
+	key      string
+	typ      reflect.Type
+	required bool
+	rules    []Rule
+}
+
+// Expect creates an expectation that key, if present, can be resolved as T and satisfies all rules.
+// Use the Required rule to also require the key to be present.
+func Expect[T any](key string, rules ...Rule) Expectation {
+	return newExpectation(key, reflect.TypeFor[T](), rules)
+}
+
+// ExpectStruct creates expectations from the fields of struct T tagged with `gconfig:"name"`.
+// Each field's key is the tag name under prefix, and the field must resolve as the field's type.
+// Add the `required` option to require the key, e.g. `gconfig:"timeout,required"`.
+func ExpectStruct[T any](prefix string) []Expectation {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("ExpectStruct requires a struct type, got %s", t))
+	}
+	result := make([]Expectation, 0, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, opts, ok := parseTag(field)
+		if !ok {
+			continue
+		}
+		var rules []Rule
+		if slices.Contains(opts, "required") {
+			rules = append(rules, Required)
+		}
+		result = append(result, newExpectation(joinKey(prefix, name), field.Type, rules))
+	}
+	return result
+}
+
+func newExpectation(key string, typ reflect.Type, rules []Rule) Expectation {
+	e := Expectation{key: key, typ: typ}
+	for _, r := range rules {
+		if _, ok := r.(requiredRule); ok {
+			e.required = true
+		} else {
+			e.rules = append(e.rules, r)
+		}
+	}
+	return e
+}
+
+// check returns all violations of the expectation in already resolved data.
+func (e Expectation) check(data map[string]any, templates templateResolvers) []string {
+	v, ok := extract(data, strings.Split(e.key, "."))
+	if !ok || v == nil {
+		if e.required {
+			return []string{fmt.Sprintf("required key `%s` is missing", e.key)}
+		}
+		return nil
+	}
+	if isUnresolvedTemplate(templates, v) {
+		return nil
+	}
+
+	ptr := reflect.New(e.typ)
+	if err := convertInto(v, ptr.Interface()); err != nil {
+		return []string{fmt.Sprintf("key `%s` cannot be read as %s: %s", e.key, e.typ, errMessage(err))}
+	}
+
+	var violations []string
+	for _, r := range e.rules {
+		if err := r.check(ptr.Elem()); err != nil {
+			violations = append(violations, fmt.Sprintf("key `%s` %s", e.key, errMessage(err)))
+		}
+	}
+	return violations
+}
+
+// Rule is a constraint on the value of an expected key.
+type Rule interface {
+	check(v reflect.Value) error
+}
+
+// Required is a rule requiring a key to be present.
+var Required Rule = requiredRule{}
+
+type requiredRule struct{}
+
+func (requiredRule) check(reflect.Value) error { return nil }
+
+type ruleFunc func(v reflect.Value) error
+
+func (f ruleFunc) check(v reflect.Value) error { return f(v) }
+
+// Min requires a value to be greater than or equal to minimum.
+// The value must be a number or string (or a type derived from one, like time.Duration).
+func Min[N cmp.Ordered](minimum N) Rule {
+	return ruleFunc(func(v reflect.Value) error {
+		c, err := compareTo(v, minimum)
+		if err != nil {
+			return err
+		} else if c < 0 {
+			return fmt.Errorf("must be at least %v but is %v", minimum, v)
+		}
+		return nil
+	})
+}
+
+// Max requires a value to be less than or equal to maximum.
+// The value must be a number or string (or a type derived from one, like time.Duration).
+func Max[N cmp.Ordered](maximum N) Rule {
+	return ruleFunc(func(v reflect.Value) error {
+		c, err := compareTo(v, maximum)
+		if err != nil {
+			return err
+		} else if c > 0 {
+			return fmt.Errorf("must be at most %v but is %v", maximum, v)
+		}
+		return nil
+	})
+}
+
+// OneOf requires a value to be one of the values provided.
+func OneOf[T comparable](values ...T) Rule {
+	return ruleFunc(func(v reflect.Value) error {
+		actual, ok := v.Interface().(T)
+		if !ok {
+			return fmt.Errorf("cannot be compared with values of type %T", *new(T))
+		}
+		if !slices.Contains(values, actual) {
+			return fmt.Errorf("must be one of %v but is %v", values, actual)
+		}
+		return nil
+	})
+}
+
+// Satisfies requires a value to pass a custom validation function.
+func Satisfies[T any](validate func(T) error) Rule {
+	return ruleFunc(func(v reflect.Value) error {
+		actual, ok := v.Interface().(T)
+		if !ok {
+			return fmt.Errorf("cannot be validated as type %T", *new(T))
+		}
+		return validate(actual)
+	})
+}
+
+// compareTo compares a value with a bound of a (possibly) different type.
+func compareTo(v reflect.Value, bound any) (int, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return 0, nil
+		}
+		v = v.Elem()
+	}
+	b := reflect.ValueOf(bound)
+	if kindClass(v.Kind()) == reflect.Invalid || kindClass(v.Kind()) != kindClass(b.Kind()) {
+		return 0, fmt.Errorf("of type %s cannot be compared with %T", v.Type(), bound)
+	}
+	switch kindClass(v.Kind()) {
+	case reflect.Int:
+		if v.CanUint() {
+			return cmp.Compare(v.Uint(), b.Convert(v.Type()).Uint()), nil
+		}
+		return cmp.Compare(v.Int(), b.Convert(v.Type()).Int()), nil
+	case reflect.Float64:
+		return cmp.Compare(v.Float(), b.Convert(v.Type()).Float()), nil
+	default:
+		return cmp.Compare(v.String(), b.String()), nil
+	}
+}
+
+// kindClass groups kinds that can be compared with each other.
+// Integers are compared in the type of the value being checked.
+func kindClass(k reflect.Kind) reflect.Kind {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflect.Int
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	case reflect.String:
+		return reflect.String
+	default:
+		return reflect.Invalid
+	}
+}
+
+// validate checks every expectation against every combination of dimension values.
+// data must be merged, but not yet reduced; it will be modified.
+func (b *Builder) validate(data map[string]any) error {
+	combinations := dimensionCombinations(b.dimensions)
+	found := newViolations()
+	for i, dims := range combinations {
+		comboData := data
+		if i < len(combinations)-1 {
+			comboData = deepCopy(data).(map[string]any)
+		}
+		for _, v := range b.validateCombination(comboData, dims) {
+			found.add(v, dimensionsLabel(dims))
+		}
+	}
+	return found.err(len(combinations))
+}
+
+// ValidateAllDimensions lints a configuration file for every combination of the values of the
+// builder's dimensions, rather than only the current one. It reports every key that fails to
+// reduce in any combination (e.g. a dimension switch without a `default` or a key for one value),
+// as well as every key whose value has a different type in different combinations.
+// The bytes are decoded by the default decoder, as with FromBytes.
+// All problems are returned together in a single ErrValidationFailed error.
+func (b *Builder) ValidateAllDimensions(bytes []byte) error {
+	data, err := decode(b.decoders.forName(""), bytes)
+	if err != nil {
+		return err
+	}
+
+	combinations := dimensionCombinations(b.dimensions)
+	found := newViolations()
+	// types holds the labels of the combinations each key resolved to each type in.
+	types := make(map[string]map[string][]string)
+	for i, dims := range combinations {
+		comboData := any(data)
+		if i < len(combinations)-1 {
+			comboData = deepCopy(data)
+		}
+		label := dimensionsLabel(dims)
+		r := b.reducer(dims)
+		r.onError = func(msg string) { found.add(msg, label) }
+		reduced, err := r.reduceAll(comboData)
+		if err != nil {
+			return err
+		}
+		walkTypes(reduced, nil, func(key, typ string) {
+			if types[key] == nil {
+				types[key] = make(map[string][]string)
+			}
+			types[key][typ] = append(types[key][typ], label)
+		})
+	}
+	// reduction visits maps in no particular order.
+	slices.Sort(found.order)
+
+	for _, key := range sortedKeys(types) {
+		byType := types[key]
+		if len(byType) < 2 {
+			continue
+		}
+		descriptions := make([]string, 0, len(byType))
+		for _, typ := range sortedKeys(byType) {
+			descriptions = append(descriptions, fmt.Sprintf("%s (when %s)", typ, strings.Join(byType[typ], "; ")))
+		}
+		found.add(fmt.Sprintf("key `%s` has different types: %s", key, strings.Join(descriptions, ", ")), "")
+	}
+	return found.err(len(combinations))
+}
+
+// walkTypes calls visit with the yaml type of every key in reduced data.
+func walkTypes(in any, paths []string, visit func(key, typ string)) {
+	key := strings.Join(paths, ".")
+	switch v := in.(type) {
+	case nil:
+		return
+	case map[string]any:
+		if len(paths) > 0 {
+			visit(key, "map")
+		}
+		for k, el := range v {
+			walkTypes(el, appendPath(paths, k), visit)
+		}
+	case map[any]any:
+		visit(key, "map")
+		for k, el := range v {
+			walkTypes(el, appendPath(paths, fmt.Sprint(k)), visit)
+		}
+	case []any:
+		visit(key, "list")
+		for i, el := range v {
+			walkTypes(el, appendPath(paths, strconv.Itoa(i)), visit)
+		}
+	case int, uint64, float64:
+		visit(key, "number")
+	default:
+		visit(key, fmt.Sprintf("%T", v))
+	}
+}
+
+// violations collects distinct violations along with the labels of the dimension combinations
+// they were found in.
+type violations struct {
+	order  []string
+	labels map[string][]string
+}
+
+func newViolations() *violations {
+	return &violations{labels: make(map[string][]string)}
+}
+
+// add records a violation found in a combination. An empty label records a violation that
+// is not specific to a combination.
+func (v *violations) add(violation, label string) {
+	if _, ok := v.labels[violation]; !ok {
+		v.order = append(v.order, violation)
+	}
+	if label != "" {
+		v.labels[violation] = append(v.labels[violation], label)
+	}
+}
+
+// err returns all violations as an ErrValidationFailed error, or nil if there are none.
+// Violations not found in every combination note the combinations they were found in.
+func (v *violations) err(combinations int) error {
+	if len(v.order) == 0 {
+		return nil
+	}
+	lines := make([]string, len(v.order))
+	for i, violation := range v.order {
+		lines[i] = violation
+		if labels := v.labels[violation]; len(labels) > 0 && len(labels) < combinations {
+			lines[i] += " (when " + strings.Join(labels, "; ") + ")"
+		}
+	}
+	return ErrValidationFailed.Msg("%d violation(s):\n\t- %s", len(lines), strings.Join(lines, "\n\t- "))
+}
+
+func (b *Builder) validateCombination(data map[string]any, dims []*dimension) []string {
+	reduced, err := b.reducer(dims).reduceAll(data)
+	if err != nil {
+		return []string{errMessage(err)}
+	}
+	result, ok := reduced.(map[string]any)
+	if !ok {
+		return []string{"unexpected non-map result"}
+	}
+	resolveTemplatesLeniently(b.templates, result)
+	b.envOverrides.apply(result, nil)
+
+	var violations []string
+	for _, e := range b.expectations {
+		violations = append(violations, e.check(result, b.templates)...)
+	}
+	return violations
+}
+
+// dimensionCombinations returns every combination of every valid value of each dimension.
+func dimensionCombinations(dimensions []*dimension) [][]*dimension {
+	result := [][]*dimension{{}}
+	for _, d := range dimensions {
+		values := d.defaultVal.StringValues()
+		next := make([][]*dimension, 0, len(result)*len(values))
+		for _, combination := range result {
+			for _, s := range values {
+				v, err := d.defaultVal.ParseGeneric(s)
+				if err != nil {
+					continue
+				}
+				fixed := &dimension{defaultVal: d.defaultVal, flagName: d.flagName, parsed: v}
+				next = append(next, append(combination[:len(combination):len(combination)], fixed))
+			}
+		}
+		result = next
+	}
+	return result
+}
+
+func dimensionsLabel(dims []*dimension) string {
+	parts := make([]string, len(dims))
+	for i, d := range dims {
+		parts[i] = d.flagName + "=" + d.get().String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// resolveTemplatesLeniently resolves every template it can, leaving the rest untouched.
+func resolveTemplatesLeniently(templates templateResolvers, in any) any {
+	switch v := in.(type) {
+	case string:
+		if out, err := parseTemplatedElements(templates, v); err == nil {
+			return out
+		}
+	case map[string]any:
+		for k, el := range v {
+			v[k] = resolveTemplatesLeniently(templates, el)
+		}
+	case []any:
+		for i, el := range v {
+			v[i] = resolveTemplatesLeniently(templates, el)
+		}
+	}
+	return in
+}
+
+func isUnresolvedTemplate(templates templateResolvers, v any) bool {
+	s, ok := v.(string)
+	if !ok {
+		return false
+	}
+	_, err := parseTemplatedElements(templates, s)
+	return err != nil
+}
+
+// errMessage returns the most readable message of an error.
+func errMessage(err error) string {
+	msg := err.Error()
+	if gerr, ok := err.(gerror.Error); ok {
+		msg = gerr.ErrMessage()
+	}
+	return strings.Join(strings.Fields(msg), " ")
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// parseTag returns the name and options of a field's `gconfig` tag.
+func parseTag(field reflect.StructField) (string, []string, bool) {
+	tag, ok := field.Tag.Lookup("gconfig")
+	if !ok || tag == "-" || !field.IsExported() {
+		return "", nil, false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, strings.Split(opts, ","), true
+}
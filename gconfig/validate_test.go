@@ -0,0 +1,134 @@
+package gconfig_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/gconfig"
+	"github.com/drshriveer/gtools/gconfig/internal"
+)
+
+type redisSettings struct {
+	Address  string        `gconfig:"address,required"`
+	MaxTries int           `gconfig:"maxTries"`
+	Timeout  time.Duration `gconfig:"timeout,required"`
+	Ignored  string
+}
+
+func TestWithExpectations(t *testing.T) {
+	tests := []struct {
+		description  string
+		expectations []gconfig.Expectation
+		expectedErrs []string
+	}{
+		{
+			description: "all valid",
+			expectations: []gconfig.Expectation{
+				gconfig.Expect[string]("runtime.mode", gconfig.Required, gconfig.OneOf("fast", "slow")),
+				gconfig.Expect[int]("runtime.max-goroutines", gconfig.Min(1), gconfig.Max(10)),
+				gconfig.Expect[string]("clients.redis.address", gconfig.Required),
+				gconfig.Expect[int]("runtime.not-required"),
+			},
+		},
+		{
+			description: "violations in some dimensions",
+			expectations: []gconfig.Expectation{
+				gconfig.Expect[time.Duration]("runtime.request-timeout",
+					gconfig.Required, gconfig.Min(10*time.Second)),
+			},
+			expectedErrs: []string{
+				"2 violation(s)",
+				"key `runtime.request-timeout` cannot be read as time.Duration",
+				"(when d1=D1b)",
+				"key `runtime.request-timeout` must be at least 10s but is 5s (when d1=D1c; d1=D1d)",
+			},
+		},
+		{
+			description: "violations in every dimension",
+			expectations: []gconfig.Expectation{
+				gconfig.Expect[int]("runtime.max-goroutines", gconfig.Max(5)),
+				gconfig.Expect[string]("runtime.mode", gconfig.Satisfies(func(s string) error {
+					return errors.New("is never valid")
+				})),
+			},
+			expectedErrs: []string{
+				"2 violation(s)",
+				"key `runtime.max-goroutines` must be at most 5 but is 10\n",
+				"key `runtime.mode` is never valid",
+			},
+		},
+		{
+			description:  "struct tags",
+			expectations: gconfig.ExpectStruct[redisSettings]("clients.redis"),
+			expectedErrs: []string{
+				"1 violation(s)",
+				"required key `clients.redis.timeout` is missing",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			cfg, err := gconfig.NewBuilder().
+				WithDimension("d1", internal.D1c).
+				WithExpectations(test.expectations...).
+				FromFile(testFS, "internal/test_validation.yaml")
+			if len(test.expectedErrs) == 0 {
+				require.NoError(t, err)
+				assert.Equal(t, "localhost:4090", gconfig.MustGet[string](cfg, "clients.redis.address"))
+				return
+			}
+
+			require.ErrorIs(t, err, gconfig.ErrValidationFailed)
+			for _, expected := range test.expectedErrs {
+				assert.Contains(t, err.Error()+"\n", expected)
+			}
+		})
+	}
+}
+
+func TestWithExpectations_EnvOverrides(t *testing.T) {
+	t.Setenv("APP__CLIENTS__REDIS__TIMEOUT", "2s")
+	t.Setenv("APP__RUNTIME__REQUEST_TIMEOUT", "20s")
+
+	cfg, err := gconfig.NewBuilder().
+		WithDimension("d1", internal.D1c).
+		WithEnvOverrides("APP", "").
+		WithExpectations(gconfig.ExpectStruct[redisSettings]("clients.redis")...).
+		WithExpectations(gconfig.Expect[time.Duration]("runtime.request-timeout", gconfig.Min(10*time.Second))).
+		FromFile(testFS, "internal/test_validation.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, gconfig.MustGet[time.Duration](cfg, "clients.redis.timeout"))
+}
+
+func TestBuilder_ValidateAllDimensions(t *testing.T) {
+	bytes, err := testFS.ReadFile("internal/test_all_dimensions.yaml")
+	require.NoError(t, err)
+
+	err = gconfig.NewBuilder().
+		WithDimension("d1", internal.D1a).
+		ValidateAllDimensions(bytes)
+	require.ErrorIs(t, err, gconfig.ErrValidationFailed)
+	for _, expected := range []string{
+		"7 violation(s)",
+		"key `missingDefault`: broken dim key! internal.DimensionOne dimensions identified around keys [D1a D1b]",
+		"but no `default` or `D1c` value found. (when d1=D1c)",
+		"but no `default` or `D1d` value found. (when d1=D1d)",
+		"key `nested.list.0`: broken dim key!",
+		"key `nested.ambiguous`: ambiguous keys! dimension keys [@D1a] are mixed with map keys [D1b]",
+		"key `typeChange` has different types: number (when d1=D1b; d1=D1c; d1=D1d), string (when d1=D1a)\n",
+	} {
+		assert.Contains(t, err.Error()+"\n", expected)
+	}
+
+	valid, err := testFS.ReadFile("internal/test.yaml")
+	require.NoError(t, err)
+	assert.NoError(t, gconfig.NewBuilder().
+		WithDimension("d1", internal.D1a).
+		WithDimension("d2", internal.D2a).
+		ValidateAllDimensions(valid))
+}
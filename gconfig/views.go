@@ -0,0 +1,63 @@
+package gconfig
+
+import (
+	"sort"
+	"strings"
+)
+
+// Has returns true if a key exists in the configuration, even if its value is null.
+func (c *Config) Has(key string) bool {
+	_, ok := extract(c.state.Load().data, strings.Split(c.fullKey(key), "."))
+	return ok
+}
+
+// Keys returns the sorted keys directly beneath prefix (an empty prefix returns the top-level keys).
+// Each key is the full path to use with Get, e.g. Keys("clients") returns `clients.redis`
+// and `clients.postgres`. Returns nil if prefix is not found or is not a map.
+func (c *Config) Keys(prefix string) []string {
+	var node any = c.state.Load().data
+	if full := c.fullKey(prefix); full != "" {
+		node, _ = extract(c.state.Load().data, strings.Split(full, "."))
+	}
+	entries, ok := mapEntries(node)
+	if !ok {
+		return nil
+	}
+	result := make([]string, 0, len(entries))
+	for k := range entries {
+		result = append(result, joinKey(prefix, k))
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Sub returns a view of the configuration scoped to the subtree under prefix, so a library can be
+// handed only its section, e.g. `cfg.Sub("clients.redis")`, and read `timeout` rather than
+// `clients.redis.timeout`.
+// A view shares dimensions, environment overrides, and cached values with the Config it was
+// created from, and sees reloads of a watched configuration. Closing a view does nothing.
+func (c *Config) Sub(prefix string) *Config {
+	if prefix == "" {
+		return c
+	}
+	return &Config{
+		dimensions:    c.dimensions,
+		templates:     c.templates,
+		sensitiveKeys: c.sensitiveKeys,
+		state:         c.state,
+		prefix:        c.fullKey(prefix),
+		subscribers:   c.subscribers,
+	}
+}
+
+// fullKey returns the key from the root of the configuration.
+func (c *Config) fullKey(key string) string {
+	switch {
+	case c.prefix == "":
+		return key
+	case key == "":
+		return c.prefix
+	default:
+		return c.prefix + "." + key
+	}
+}
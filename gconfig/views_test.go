@@ -0,0 +1,93 @@
+package gconfig_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/gconfig"
+	"github.com/drshriveer/gtools/gconfig/internal"
+)
+
+func TestConfig_Views(t *testing.T) {
+	t.Setenv("VIEW__SERVICE__TAGS__TIER", "2")
+	t.Setenv("VIEW__SERVICE__TAGS__REGION", "eu")
+
+	cfg, err := gconfig.NewBuilder().
+		WithDimensionValue("view-d1", internal.D1c, internal.D1b).
+		WithEnvOverrides("VIEW", "").
+		WithSensitiveKeys("service.tags.team").
+		FromLayers(
+			gconfig.FileLayer(testFS, "internal/test_layer_base.yaml"),
+			gconfig.FileLayer(testFS, "internal/test_layer_overlay.yaml"),
+		)
+	require.NoError(t, err)
+
+	t.Run("Has", func(t *testing.T) {
+		assert.True(t, cfg.Has("service"))
+		assert.True(t, cfg.Has("service.tags.owner"))
+		assert.True(t, cfg.Has("service.tags.region"), "keys supplied by environment overrides exist")
+		assert.False(t, cfg.Has("service.missing"))
+		assert.False(t, cfg.Has("service.name.child"))
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		assert.Equal(t, []string{"service"}, cfg.Keys(""))
+		assert.Equal(t,
+			[]string{"service.hosts", "service.name", "service.tags", "service.timeout"},
+			cfg.Keys("service"))
+		assert.Equal(t,
+			[]string{"service.tags.owner", "service.tags.region", "service.tags.team", "service.tags.tier"},
+			cfg.Keys("service.tags"), "keys only supplied by environment overrides are listed")
+		assert.Nil(t, cfg.Keys("service.name"))
+		assert.Nil(t, cfg.Keys("service.missing"))
+	})
+
+	sub := cfg.Sub("service")
+	tags := sub.Sub("tags")
+
+	t.Run("Get", func(t *testing.T) {
+		assert.Equal(t, "base-service", gconfig.MustGet[string](sub, "name"))
+		assert.Equal(t, 2*time.Second, gconfig.MustGet[time.Duration](sub, "timeout"))
+		assert.Equal(t, 2, gconfig.MustGet[int](tags, "tier"))
+		assert.Equal(t, "overlay", gconfig.MustGet[string](tags, "team"))
+		_, err := gconfig.Get[string](sub, "missing")
+		assert.ErrorIs(t, err, gconfig.ErrConfigFailure)
+		assert.Equal(t, internal.D1b, gconfig.GetDimension[internal.DimensionOne](tags))
+	})
+
+	t.Run("Has and Keys", func(t *testing.T) {
+		assert.True(t, sub.Has("tags.team"))
+		assert.False(t, sub.Has("service"))
+		assert.Equal(t, []string{"tags.owner", "tags.region", "tags.team", "tags.tier"}, sub.Keys("tags"))
+		assert.Equal(t, []string{"owner", "region", "team", "tier"}, tags.Keys(""))
+	})
+
+	t.Run("Bind", func(t *testing.T) {
+		type tagSettings struct {
+			Team string `gconfig:"team"`
+			Tier int    `gconfig:"tier"`
+		}
+		assert.Equal(t, tagSettings{Team: "overlay", Tier: 2}, gconfig.MustBind[tagSettings](tags, ""))
+		assert.Equal(t, tagSettings{Team: "overlay", Tier: 2}, gconfig.MustBind[tagSettings](sub, "tags"))
+	})
+
+	t.Run("Origins", func(t *testing.T) {
+		origins, ok := sub.Origins("tags.owner")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"internal/test_layer_overlay.yaml"}, origins)
+	})
+
+	t.Run("Dump", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, tags.Dump(buf, gconfig.DumpYAML))
+		assert.Equal(t, "owner: someone\nregion: eu\nteam: '[REDACTED]'\ntier: \"2\"\n", buf.String())
+	})
+
+	t.Run("Sub of empty prefix", func(t *testing.T) {
+		assert.Same(t, cfg, cfg.Sub(""))
+	})
+}
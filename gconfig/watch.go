@@ -0,0 +1,201 @@
+package gconfig
+
+import (
+	"context"
+	"io/fs"
+	"log"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+)
+
+const defaultReloadInterval = 10 * time.Second
+
+// WithReloadInterval sets how often a watched configuration file is checked for changes.
+// Defaults to 10 seconds.
+func (b *Builder) WithReloadInterval(interval time.Duration) *Builder {
+	b.reloadInterval = interval
+	return b
+}
+
+// WithReloadErrorHandler sets a callback that receives errors from failed reloads of a watched
+// configuration. When a reload fails the last successfully parsed configuration stays in use.
+// A source that keeps failing to fetch is only reported once, until a fetch succeeds again.
+// By default errors are logged.
+func (b *Builder) WithReloadErrorHandler(onError func(err error)) *Builder {
+	b.onReloadError = onError
+	return b
+}
+
+// Watch parses a Config from a configuration file exactly like FromFile, then keeps polling
+// the file for changes. When the file changes it is re-parsed and, if successful, swapped in
+// atomically; previously cached values are discarded and subscribers (see Subscribe) are notified.
+// Call Config.Close to stop watching.
+func (b *Builder) Watch(fileSystem fs.FS, filename string) (*Config, error) {
+	return b.WatchSource(context.Background(), FileSource(fileSystem, filename))
+}
+
+// WatchSource parses a Config from a source like FromSource, then polls the source for changes
+// every reload interval (see WithReloadInterval) exactly like Watch.
+// ctx applies to the initial fetch; call Config.Close to stop watching.
+func (b *Builder) WatchSource(ctx context.Context, src Source) (*Config, error) {
+	dims, err := b.resolveDimensions()
+	if err != nil {
+		return nil, err
+	}
+	raw, version, _, err := src.Fetch(ctx, "")
+	if err != nil {
+		return nil, ErrFailedParsing.Convert(err)
+	}
+
+	parse := func(raw []byte) (*configState, error) {
+		return b.build(dims, []Layer{BytesLayer(src.Name(), raw)})
+	}
+	state, err := parse(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := b.newConfig(dims, state)
+	watchCtx, cancel := context.WithCancel(context.Background())
+	cfg.watcher = &watcher{
+		cfg:      cfg,
+		ctx:      watchCtx,
+		src:      src,
+		parse:    parse,
+		version:  version,
+		interval: b.reloadInterval,
+		onError:  b.onReloadError,
+		cancel:   cancel,
+	}
+	if cfg.watcher.interval <= 0 {
+		cfg.watcher.interval = defaultReloadInterval
+	}
+	if cfg.watcher.onError == nil {
+		cfg.watcher.onError = func(err error) {
+			log.Printf("[WARN] - failed to reload configuration %s: %+v", src.Name(), err)
+		}
+	}
+	go cfg.watcher.run()
+
+	return cfg, nil
+}
+
+// Subscribe registers a callback that is invoked whenever a reload of a watched Config changes
+// the value of key when resolved as T. If the key did not exist before the reload, oldValue will be
+// the zero value of T. Reloads where the key cannot be resolved as T are skipped.
+// Callbacks are called synchronously, in the order of subscription, from the goroutine watching
+// the configuration. The returned function removes the subscription.
+func Subscribe[T any](cfg *Config, key string, onChange func(oldValue, newValue T)) (unsubscribe func()) {
+	key = cfg.fullKey(key)
+	return cfg.subscribers.add(func(oldState, newState *configState) {
+		newV, err := getFromState[T](cfg, newState, key)
+		if err != nil {
+			return
+		}
+		oldV, err := getFromState[T](cfg, oldState, key)
+		if err == nil && reflect.DeepEqual(oldV, newV) {
+			return
+		}
+		onChange(oldV, newV)
+	})
+}
+
+// Close stops watching the configuration's source for changes.
+// It is safe to call on configurations that are not watched and to call more than once.
+func (c *Config) Close() error {
+	if c.watcher != nil {
+		c.watcher.cancel()
+	}
+	return nil
+}
+
+// watcher polls a configuration's source and swaps in the result when it changes.
+type watcher struct {
+	cfg      *Config
+	ctx      context.Context
+	src      Source
+	parse    func([]byte) (*configState, error)
+	version  string
+	interval time.Duration
+	onError  func(error)
+	cancel   context.CancelFunc
+	// fetchFailing is set while fetches fail so that an outage is only reported once.
+	fetchFailing bool
+}
+
+func (w *watcher) run() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			w.reload()
+		}
+	}
+}
+
+func (w *watcher) reload() {
+	raw, version, changed, err := w.src.Fetch(w.ctx, w.version)
+	if err != nil {
+		if w.ctx.Err() == nil && !w.fetchFailing {
+			w.onError(err)
+		}
+		w.fetchFailing = true
+		return
+	}
+	w.fetchFailing = false
+	if !changed {
+		return
+	}
+	// record the attempt even if it fails so that a broken file is only reported once.
+	w.version = version
+
+	newState, err := w.parse(raw)
+	if err != nil {
+		w.onError(err)
+		return
+	}
+
+	oldState := w.cfg.state.Swap(newState)
+	w.cfg.subscribers.notify(oldState, newState)
+}
+
+// subscribers is a registry of reload callbacks.
+type subscribers struct {
+	mu     sync.Mutex
+	nextID uint64
+	subs   []subscription
+}
+
+type subscription struct {
+	id       uint64
+	onReload func(oldState, newState *configState)
+}
+
+func (s *subscribers) add(onReload func(oldState, newState *configState)) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.nextID
+	s.nextID++
+	s.subs = append(s.subs, subscription{id: id, onReload: onReload})
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.subs = slices.DeleteFunc(s.subs, func(sub subscription) bool { return sub.id == id })
+	}
+}
+
+func (s *subscribers) notify(oldState, newState *configState) {
+	s.mu.Lock()
+	subs := slices.Clone(s.subs)
+	s.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.onReload(oldState, newState)
+	}
+}
@@ -0,0 +1,132 @@
+package gconfig_test
+
+import (
+	"io/fs"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/gconfig"
+	"github.com/drshriveer/gtools/gconfig/internal"
+)
+
+// mutableFS is a thread-safe, single-file fs.FS whose contents can be changed by tests.
+type mutableFS struct {
+	mu       sync.Mutex
+	contents string
+}
+
+func (m *mutableFS) Open(name string) (fs.File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return fstest.MapFS{"config.yaml": {Data: []byte(m.contents)}}.Open(name)
+}
+
+func (m *mutableFS) set(contents string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.contents = contents
+}
+
+func TestWatch(t *testing.T) {
+	fileSystem := &mutableFS{contents: `
+server:
+  timeout: 1s
+  name:
+    D1a: first
+    default: other
+`}
+
+	var errMu sync.Mutex
+	var reloadErrs []error
+	cfg, err := gconfig.NewBuilder().
+		WithDimension("d1", internal.D1a).
+		WithReloadInterval(5 * time.Millisecond).
+		WithReloadErrorHandler(func(err error) {
+			errMu.Lock()
+			defer errMu.Unlock()
+			reloadErrs = append(reloadErrs, err)
+		}).
+		Watch(fileSystem, "config.yaml")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, cfg.Close()) })
+
+	assert.Equal(t, time.Second, gconfig.MustGet[time.Duration](cfg, "server.timeout"))
+	assert.Equal(t, "first", gconfig.MustGet[string](cfg, "server.name"))
+
+	changes := make(chan [2]time.Duration, 10)
+	unsubscribe := gconfig.Subscribe(cfg, "server.timeout", func(oldValue, newValue time.Duration) {
+		changes <- [2]time.Duration{oldValue, newValue}
+	})
+	nameChanges := make(chan string, 10)
+	gconfig.Subscribe(cfg, "server.name", func(_, newValue string) {
+		nameChanges <- newValue
+	})
+
+	t.Run("reloads on change", func(t *testing.T) {
+		fileSystem.set(`
+server:
+  timeout: 2s
+  name:
+    D1a: first
+    default: other
+`)
+		select {
+		case change := <-changes:
+			assert.Equal(t, [2]time.Duration{time.Second, 2 * time.Second}, change)
+		case <-time.After(time.Second):
+			require.Fail(t, "timed out waiting for reload")
+		}
+		assert.Equal(t, 2*time.Second, gconfig.MustGet[time.Duration](cfg, "server.timeout"))
+		// unchanged values do not notify.
+		assert.Empty(t, nameChanges)
+	})
+
+	t.Run("keeps last good config on failure", func(t *testing.T) {
+		fileSystem.set(`
+server:
+  timeout: 3s
+  name:
+    D1b: missing default
+`)
+		assert.Eventually(t, func() bool {
+			errMu.Lock()
+			defer errMu.Unlock()
+			return len(reloadErrs) == 1
+		}, time.Second, 5*time.Millisecond)
+		errMu.Lock()
+		assert.ErrorIs(t, reloadErrs[0], gconfig.ErrFailedParsing)
+		errMu.Unlock()
+		assert.Equal(t, 2*time.Second, gconfig.MustGet[time.Duration](cfg, "server.timeout"))
+		assert.Equal(t, "first", gconfig.MustGet[string](cfg, "server.name"))
+	})
+
+	t.Run("unsubscribe", func(t *testing.T) {
+		unsubscribe()
+		fileSystem.set(`
+server:
+  timeout: 4s
+  name: renamed
+`)
+		select {
+		case name := <-nameChanges:
+			assert.Equal(t, "renamed", name)
+		case <-time.After(time.Second):
+			require.Fail(t, "timed out waiting for reload")
+		}
+		assert.Equal(t, 4*time.Second, gconfig.MustGet[time.Duration](cfg, "server.timeout"))
+		assert.Empty(t, changes)
+	})
+}
+
+func TestWatch_InitialFailure(t *testing.T) {
+	_, err := gconfig.NewBuilder().Watch(&mutableFS{contents: "not: [valid"}, "config.yaml")
+	assert.ErrorIs(t, err, gconfig.ErrFailedParsing)
+
+	_, err = gconfig.NewBuilder().Watch(&mutableFS{}, "missing.yaml")
+	assert.ErrorIs(t, err, gconfig.ErrFailedParsing)
+}
@@ -1,67 +1,189 @@
 package gconfig
 
 import (
+	"encoding/base64"
+	"errors"
+	"io/fs"
 	"os"
 	"regexp"
 	"strings"
 )
 
-type templateVariable interface {
-	MatchAndResolve(in string) (string, bool, error)
+const (
+	envTemplateName    = "env"
+	fileTemplateName   = "file"
+	base64TemplateName = "base64"
+	secretTemplateName = "secret"
+)
+
+// TemplateResolver resolves template variables of the form `${{name: argument}}`.
+// Resolvers are registered by name with Builder.WithTemplateResolver.
+//
+// All templates support a default value used when the variable is not found:
+// `${{name: argument | default}}`.
+type TemplateResolver interface {
+	// Resolve returns the value of a template variable, or false if it was not found.
+	Resolve(argument string) (value string, ok bool, err error)
 }
 
-var templates = []templateVariable{
-	&envVarTmpl{},
+// TemplateResolverFunc adapts a function to a TemplateResolver.
+type TemplateResolverFunc func(argument string) (string, bool, error)
+
+// Resolve calls the function.
+func (f TemplateResolverFunc) Resolve(argument string) (string, bool, error) {
+	return f(argument)
 }
 
-type envVarTmpl struct{}
+// SecretProvider looks up secrets by name for `${{secret: name}}` templates.
+// Register one with Builder.WithSecretProvider.
+type SecretProvider interface {
+	// GetSecret returns the value of a secret, or false if it does not exist.
+	GetSecret(name string) (value string, ok bool, err error)
+}
 
-var envVarTmplMatcher = regexp.MustCompile(`^\$\{\{\s*env:\s*(\w+)\s*\|?\s*(.*\S)?\s*\}\}$`)
+// WithTemplateResolver registers a resolver for templates named name, e.g. with the name `vault`
+// the resolver is used for `${{vault: path/to/secret}}`. Registering a name again replaces
+// its resolver, including the built-in `env`, `file`, and `base64` resolvers.
+// A nil resolver fails the configuration when it is built.
+func (b *Builder) WithTemplateResolver(name string, resolver TemplateResolver) *Builder {
+	b.templates = b.templates.with(name, resolver)
+	return b
+}
 
-func (envVarTmpl) MatchAndResolve(in string) (out string, ok bool, err error) {
-	out = in
-	matches := envVarTmplMatcher.FindStringSubmatch(in)
-	if len(matches) == 0 {
-		return out, false, nil
+// WithSecretProvider resolves `${{secret: name}}` templates with provider.
+// A nil provider fails the configuration when it is built.
+func (b *Builder) WithSecretProvider(provider SecretProvider) *Builder {
+	if provider == nil {
+		return b.WithTemplateResolver(secretTemplateName, nil)
 	}
-	envVarName := matches[1]
-	out, ok = os.LookupEnv(envVarName)
-	if !ok {
-		// This is the "default" case, where the environment variable is not found.
-		if len(matches) == 3 && matches[2] != "" {
-			out = strings.Trim(matches[2], `"`)
-			return out, true, nil
+	return b.WithTemplateResolver(secretTemplateName, TemplateResolverFunc(provider.GetSecret))
+}
+
+// templateResolvers is a registry of template resolvers by name.
+type templateResolvers map[string]TemplateResolver
+
+func defaultTemplateResolvers() templateResolvers {
+	return templateResolvers{
+		envTemplateName:    envVarTmpl{},
+		fileTemplateName:   fileTmpl{},
+		base64TemplateName: base64Tmpl{},
+	}
+}
+
+// with returns a copy of the registry with an additional resolver, so builders never share
+// registrations.
+func (t templateResolvers) with(name string, resolver TemplateResolver) templateResolvers {
+	result := make(templateResolvers, len(t)+1)
+	for k, v := range t {
+		result[k] = v
+	}
+	result[name] = resolver
+	return result
+}
+
+// validate reports resolvers that were registered as nil.
+func (t templateResolvers) validate() error {
+	for _, name := range sortedKeys(t) {
+		if t[name] == nil {
+			return ErrFailedParsing.Msg("template resolver `%s` is nil", name)
+		}
+	}
+	return nil
+}
+
+var templateMatcher = regexp.MustCompile(`\$\{\{\s*(\w+)\s*:\s*([^|}]*?)\s*(?:\|\s*(.*?))?\s*\}\}`)
+
+// resolve replaces every template in a string, which may be embedded in a larger string
+// e.g. `redis://${{env: HOST}}:6379`. It returns false if the string contains no templates.
+func (t templateResolvers) resolve(in string) (out string, ok bool, err error) {
+	if !strings.Contains(in, "${{") {
+		return in, false, nil
+	}
+	out = templateMatcher.ReplaceAllStringFunc(in, func(match string) string {
+		if err != nil {
+			return match
 		}
+		ok = true
+		var s string
+		s, err = t.resolveOne(templateMatcher.FindStringSubmatch(match))
+		return s
+	})
+	if err != nil {
+		return "", false, err
+	}
+	return out, ok, nil
+}
 
-		return out, false, ErrFailedParsing.Msg(
-			"templated environment environment variable %s not found in env",
-			envVarName)
+func (t templateResolvers) resolveOne(matches []string) (string, error) {
+	name, argument, defaultVal := matches[1], matches[2], matches[3]
+	resolver, ok := t[name]
+	if !ok {
+		return "", ErrFailedParsing.Msg("no template resolver registered for `%s`", name)
+	}
+	out, ok, err := resolver.Resolve(argument)
+	if err != nil {
+		return "", ErrFailedParsing.Convert(err)
+	} else if ok {
+		return out, nil
+	}
+	// This is the "default" case, where the variable is not found.
+	if defaultVal != "" {
+		return strings.Trim(defaultVal, `"`), nil
 	}
-	return out, true, nil
+	return "", ErrFailedParsing.Msg("templated %s variable %s not found", name, argument)
 }
 
-func parseTemplatedElements[T any](in T) (out T, err error) {
+type envVarTmpl struct{}
+
+func (envVarTmpl) Resolve(argument string) (string, bool, error) {
+	out, ok := os.LookupEnv(argument)
+	return out, ok, nil
+}
+
+// fileTmpl resolves to the contents of a file, without trailing newlines, e.g. a mounted secret.
+type fileTmpl struct{}
+
+func (fileTmpl) Resolve(argument string) (string, bool, error) {
+	//nolint:gosec // G304 reading the file named by the configuration is the point.
+	b, err := os.ReadFile(argument)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+	return strings.TrimRight(string(b), "\r\n"), true, nil
+}
+
+// base64Tmpl resolves to the decoded value of a standard base64 string.
+type base64Tmpl struct{}
+
+func (base64Tmpl) Resolve(argument string) (string, bool, error) {
+	b, err := base64.StdEncoding.DecodeString(argument)
+	if err != nil {
+		return "", false, err
+	}
+	return string(b), true, nil
+}
+
+func parseTemplatedElements[T any](templates templateResolvers, in T) (out T, err error) {
 	switch v := any(in).(type) {
 	case string:
-		for _, template := range templates {
-			temp, ok, err := template.MatchAndResolve(v)
-			if err != nil {
-				return out, err
-			} else if ok {
-				return any(temp).(T), nil
-			}
-			// else try next template.
+		temp, ok, err := templates.resolve(v)
+		if err != nil {
+			return out, err
+		} else if ok {
+			return any(temp).(T), nil
 		}
 	case map[string]any:
 		for k, el := range v {
-			v[k], err = parseTemplatedElements(el)
+			v[k], err = parseTemplatedElements(templates, el)
 			if err != nil {
 				return out, err
 			}
 		}
 	case []any:
 		for i, el := range v {
-			v[i], err = parseTemplatedElements(el)
+			v[i], err = parseTemplatedElements(templates, el)
 			if err != nil {
 				return out, err
 			}
@@ -2,13 +2,15 @@ package gconfig
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func TestEnvVarTmpl_MatchAndResolve(t *testing.T) {
+func TestEnvVarTmpl_Resolve(t *testing.T) {
 	envVarKey := "MY_ENV_VAR"
 	tests := []struct {
 		description string
@@ -92,7 +94,7 @@ func TestEnvVarTmpl_MatchAndResolve(t *testing.T) {
 					require.NoError(t, os.Unsetenv(envVarKey))
 				})
 			}
-			result, used, err := envVarTmpl{}.MatchAndResolve(test.input)
+			result, used, err := templateResolvers{envTemplateName: envVarTmpl{}}.resolve(test.input)
 			assert.ErrorIs(t, err, test.expectedError)
 			assert.Equal(t, test.expectedUsed, used)
 			assert.Equal(t, test.expectedOutput, result)
@@ -100,3 +102,119 @@ func TestEnvVarTmpl_MatchAndResolve(t *testing.T) {
 		})
 	}
 }
+
+type mapSecrets map[string]string
+
+func (m mapSecrets) GetSecret(name string) (string, bool, error) {
+	v, ok := m[name]
+	return v, ok, nil
+}
+
+func TestTemplateResolvers_Resolve(t *testing.T) {
+	t.Setenv("TMPL_HOST", "redis.local")
+	secretFile := filepath.Join(t.TempDir(), "db_pass")
+	require.NoError(t, os.WriteFile(secretFile, []byte("hunter2\n"), 0o600))
+
+	resolvers := defaultTemplateResolvers().
+		with(secretTemplateName, TemplateResolverFunc(mapSecrets{"db": "s3cret"}.GetSecret))
+
+	tests := []struct {
+		description string
+		input       string
+
+		expectedOutput string
+		expectedUsed   bool
+		expectedError  error
+	}{
+		{
+			description:    "not a template",
+			input:          "redis://localhost:6379",
+			expectedOutput: "redis://localhost:6379",
+		},
+		{
+			description:    "embedded template",
+			input:          "redis://${{env: TMPL_HOST}}:6379",
+			expectedOutput: "redis://redis.local:6379",
+			expectedUsed:   true,
+		},
+		{
+			description:    "multiple embedded templates",
+			input:          "${{env: TMPL_HOST}}/${{ secret: db }}/${{env: TMPL_UNSET | fallback}}",
+			expectedOutput: "redis.local/s3cret/fallback",
+			expectedUsed:   true,
+		},
+		{
+			description:    "file",
+			input:          "${{file: " + secretFile + "}}",
+			expectedOutput: "hunter2",
+			expectedUsed:   true,
+		},
+		{
+			description:    "missing file with default",
+			input:          "${{file: /does/not/exist | none}}",
+			expectedOutput: "none",
+			expectedUsed:   true,
+		},
+		{
+			description:   "missing file",
+			input:         "${{file: /does/not/exist}}",
+			expectedError: ErrFailedParsing,
+		},
+		{
+			description:    "base64",
+			input:          "${{base64: aGVsbG8gd29ybGQ=}}",
+			expectedOutput: "hello world",
+			expectedUsed:   true,
+		},
+		{
+			description:   "invalid base64",
+			input:         "${{base64: not base64!}}",
+			expectedError: ErrFailedParsing,
+		},
+		{
+			description:   "missing secret",
+			input:         "${{secret: nope}}",
+			expectedError: ErrFailedParsing,
+		},
+		{
+			description:   "unregistered resolver",
+			input:         "${{vault: a/b}}",
+			expectedError: ErrFailedParsing,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, used, err := resolvers.resolve(test.input)
+			assert.ErrorIs(t, err, test.expectedError)
+			assert.Equal(t, test.expectedUsed, used)
+			assert.Equal(t, test.expectedOutput, result)
+		})
+	}
+}
+
+func TestBuilder_WithSecretProvider(t *testing.T) {
+	t.Setenv("TMPL_HOST", "redis.local")
+	cfg, err := NewBuilder().
+		WithSecretProvider(mapSecrets{"redis-password": "s3cret"}).
+		WithTemplateResolver("upper", TemplateResolverFunc(func(argument string) (string, bool, error) {
+			return strings.ToUpper(argument), true, nil
+		})).
+		FromBytes([]byte(`
+redis:
+  url: "redis://:${{secret: redis-password}}@${{env: TMPL_HOST}}:6379"
+  name: "${{upper: cache}}"
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "redis://:s3cret@redis.local:6379", MustGet[string](cfg, "redis.url"))
+	assert.Equal(t, "CACHE", MustGet[string](cfg, "redis.name"))
+
+	// secrets are not available without a provider.
+	_, err = NewBuilder().FromBytes([]byte(`password: "${{secret: redis-password}}"`))
+	assert.ErrorIs(t, err, ErrFailedParsing)
+
+	// a nil provider is rejected rather than panicking when a secret is resolved.
+	_, err = NewBuilder().WithSecretProvider(nil).FromBytes([]byte(`password: "${{secret: redis-password}}"`))
+	assert.ErrorIs(t, err, ErrFailedParsing)
+	assert.ErrorContains(t, err, "template resolver `secret` is nil")
+}
@@ -0,0 +1,13 @@
+// Command genum-lint reports switch statements and map literals over genum enums that do not
+// cover every value of the enum.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/drshriveer/gtools/genum/lint"
+)
+
+func main() {
+	singlechecker.Main(lint.Analyzer)
+}
@@ -20,6 +20,12 @@ func main() {
 
 	g.InFile = gencommon.SanitizeSourceFile(g.InFile)
 	g.OutFile = gencommon.SanitizeOutFile(g.OutFile, g.InFile, "genum")
+	if g.ProtoOut != "" {
+		g.ProtoOut = gencommon.SanitizeOutFile(g.ProtoOut, g.InFile, "proto")
+	}
+	if g.SchemaOut != "" {
+		g.SchemaOut = gencommon.SanitizeOutFile(g.SchemaOut, g.InFile, "json")
+	}
 
 	if len(g.Types) == 0 {
 		log.Fatal("type is required")
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/itzg/go-flagsfiller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/genum/gen"
+)
+
+// TestFlags guards against flag names that collide, which panic when the command starts.
+func TestFlags(t *testing.T) {
+	g := gen.Generate{}
+	flagSet := flag.NewFlagSet("genum", flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+	require.NotPanics(t, func() {
+		require.NoError(t, flagsfiller.New().Fill(flagSet, &g))
+	})
+
+	require.NoError(t, flagSet.Parse([]string{
+		"-types=Stage,Phase",
+		"-flags",
+		"-sql",
+		"-protoTypes=example.com/pb.Stage,example.com/pb.Phase",
+		"-transform=trimPrefix,kebab",
+		"-order=declaration",
+		"-schemaOut=schema.json",
+	}))
+	assert.Equal(t, []string{"Stage", "Phase"}, g.Types)
+	assert.True(t, g.GenFlags)
+	assert.True(t, g.GenSQL)
+	assert.Equal(t, []string{"example.com/pb.Stage", "example.com/pb.Phase"}, g.ProtoTypes)
+	assert.Equal(t, []string{"trimPrefix", "kebab"}, g.Transform)
+	assert.Equal(t, "declaration", g.Order)
+	assert.Equal(t, "schema.json", g.SchemaOut)
+	assert.Equal(t, "jsonschema", g.SchemaFormat)
+	assert.True(t, g.GenJSON)
+}
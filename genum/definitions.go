@@ -3,7 +3,8 @@ package genum
 // EnumLike is a generic type for something that looks like an enum.
 type EnumLike interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 |
-		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~string
 }
 
 // Enum is the base interface all generated enums implement.
@@ -32,6 +33,7 @@ type TypedEnum[T EnumLike] interface {
 	Enum
 
 	// Values returns all valid values of an enum.
+	// Bit-flag enums (see the -flags option) instead return the flags set in the value.
 	Values() []T
 
 	// ParseString converts text into a type if valid.
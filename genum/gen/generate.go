@@ -7,12 +7,15 @@ import (
 	"go/constant"
 	"go/types"
 	"log"
+	"math"
+	"reflect"
 	"slices"
 	"sort"
 	"strings"
 	"text/template"
 
 	"github.com/drshriveer/gtools/gencommon"
+	"github.com/drshriveer/gtools/genum/internal/astutil"
 	"github.com/drshriveer/gtools/set"
 )
 
@@ -22,6 +25,12 @@ var tmpl string
 // enumTemplate is the base template for an enum.
 var enumTemplate = template.Must(template.New("genum").Parse(tmpl))
 
+//go:embed protoTemplate.gotmpl
+var protoTmpl string
+
+// protoTemplate is the template for .proto enum definitions.
+var protoTemplate = template.Must(template.New("genum-proto").Parse(protoTmpl))
+
 // Generate is the parser and writer of enums and their generated code.
 // It seems to double as its own 'options' holder.
 type Generate struct {
@@ -34,17 +43,65 @@ type Generate struct {
 	DisableTraits    bool     `aliases:"disableTraits" default:"false" usage:"disable trait syntax inspection"`
 	CaseInsensitive  bool     `aliases:"caseInsensitive" default:"false" usage:"parsing will be case insensitive"`
 	ParsableByTraits []string `aliases:"parsableByTraits" usage:"Comma separated list of trait names which will generate their own parser. This will throw an error if the values of that trait are not unique or the trait does not exist."`
+	GenFlags         bool     `aliases:"flags" default:"false" usage:"generate bit-flag methods; enum values must be zero or a power of two"`
+	GenSQL           bool     `aliases:"sql" default:"false" usage:"generate database/sql Valuer and Scanner methods"`
+	SQLAsString      bool     `aliases:"sqlAsString" default:"false" usage:"store sql values as their string form rather than their integer value"`
+	ProtoTypes       []string `aliases:"protoTypes" usage:"comma-separated, fully-qualified protoc-generated enum types (e.g. github.com/org/api/pb.Stage) to generate ToProto and FromProto converters for; one for each type, in the same order."`
+	ProtoOut         string   `aliases:"protoOut" usage:"path of a .proto file to write enum definitions to (optional)"`
+	ProtoPackage     string   `aliases:"protoPackage" usage:"package of the .proto file written to protoOut (defaults to the go package name)"`
+	Transform        []string `usage:"comma-separated transforms applied to value names in their string form: one of snake, kebab, or screaming, and/or trimPrefix to remove the type's name. Values remain parsable by their go names."`
+	Order            string   `default:"value" usage:"order of values returned by Values and All, and used by Next, Prev, Ordinal, and Compare: value (numeric, or lexical for string-backed enums) or declaration"`
+	GenOrdering      bool     `aliases:"ordering" default:"false" usage:"generate ordering and iteration methods (Min, Max, Ordinal, Next, Prev, Compare, and All); requires go 1.23"`
+	SchemaOut        string   `aliases:"schemaOut" usage:"path of a .json file to write JSON Schema definitions of the enums to (optional; requires json)"`
+	SchemaFormat     string   `aliases:"schemaFormat" default:"jsonschema" usage:"format of the file written to schemaOut: jsonschema (definitions under $defs) or openapi (definitions under components.schemas)"`
 
 	// derived, (exposed for template use):
-	Values  []Values                 `flag:""` // ignore these fields
-	Traits  []TraitDescs             `flag:""` // ignore these fields
-	Imports *gencommon.ImportHandler `flag:""` // ignore these fields
-	PkgName string                   `flag:""` // ignore these fields
+	Values      []Values                 `flag:""` // ignore these fields
+	Traits      []TraitDescs             `flag:""` // ignore these fields
+	Protos      []*ProtoMapping          `flag:""` // ignore these fields
+	Transitions []Transitions            `flag:""` // ignore these fields
+	ProtoEnums  []ProtoEnum              `flag:""` // ignore these fields
+	Imports     *gencommon.ImportHandler `flag:""` // ignore these fields
+	PkgName     string                   `flag:""` // ignore these fields
+
+	typeDocs []*ast.CommentGroup
 }
 
+const (
+	orderValue       = "value"
+	orderDeclaration = "declaration"
+)
+
 // Parse the input file and drives the attributes above.
 func (g *Generate) Parse() error {
-	_, pkg, fAST, importInfo, err := gencommon.LoadPackages(g.InFile)
+	if g.Order != "" && g.Order != orderValue && g.Order != orderDeclaration {
+		return fmt.Errorf("unknown order %s; expected one of %s or %s", g.Order, orderValue, orderDeclaration)
+	}
+	if g.SchemaFormat != "" && g.SchemaFormat != schemaFormatJSONSchema && g.SchemaFormat != schemaFormatOpenAPI {
+		return fmt.Errorf("unknown schema format %s; expected one of %s or %s",
+			g.SchemaFormat, schemaFormatJSONSchema, schemaFormatOpenAPI)
+	}
+	if g.SchemaOut != "" && !g.GenJSON {
+		return fmt.Errorf("schemaOut requires json marshal methods, which define the form values are described in")
+	}
+	if len(g.ProtoTypes) > 0 && len(g.ProtoTypes) != len(g.Types) {
+		return fmt.Errorf("expected one proto type for each of %d types, found %d", len(g.Types), len(g.ProtoTypes))
+	}
+	protoPkgs := make([]string, 0, len(g.ProtoTypes))
+	for _, protoType := range g.ProtoTypes {
+		pkgPath, _, err := splitProtoType(protoType)
+		if err != nil {
+			return err
+		}
+		protoPkgs = append(protoPkgs, pkgPath)
+	}
+
+	transform, err := nameTransform(g.Transform)
+	if err != nil {
+		return err
+	}
+
+	pkgs, pkg, fAST, importInfo, err := gencommon.LoadPackages(g.InFile, protoPkgs...)
 	if err != nil {
 		return err
 	}
@@ -54,6 +111,10 @@ func (g *Generate) Parse() error {
 	pkgScope := pkg.Types.Scope()
 	g.Values = make([]Values, len(g.Types))
 	g.Traits = make([]TraitDescs, len(g.Types))
+	g.Protos = make([]*ProtoMapping, len(g.Types))
+	g.Transitions = make([]Transitions, len(g.Types))
+	g.typeDocs = make([]*ast.CommentGroup, len(g.Types))
+	g.ProtoEnums = nil
 	for i, enumType := range g.Types {
 		values := make(Values, 0)
 		for _, decl := range fAST.Decls {
@@ -68,21 +129,64 @@ func (g *Generate) Parse() error {
 					if !ok || (v.Type().String() != enumType && !strings.HasSuffix(v.Type().String(), "."+enumType)) {
 						continue
 					}
-					value, isUint := constant.Uint64Val(v.Val())
 					enumValue := Value{
 						Name:         vName,
-						Value:        value,
-						Signed:       !isUint,
-						IsDeprecated: isDeprecated(fAST, vName),
+						IsDeprecated: astutil.IsDeprecated(fAST, vName),
 						Line:         pkg.Fset.Position(v.Pos()).Line,
+						Description:  valueDescription(vSpec),
 						astLine:      vSpec,
 					}
+					if v.Val().Kind() == constant.String {
+						enumValue.IsString = true
+						enumValue.StringValue = constant.StringVal(v.Val())
+					} else {
+						value, isUint := constant.Uint64Val(v.Val())
+						enumValue.Value = value
+						enumValue.Signed = !isUint
+						enumValue.DisplayName = transform(vName, enumType)
+					}
+					if name, aliases, ok := nameTag(vSpec); ok {
+						enumValue.Aliases = aliases
+						if name != "" {
+							enumValue.DisplayName = name
+						}
+					}
 					values = append(values, enumValue)
 				}
 			}
 		}
 		sort.Sort(values)
 		g.Values[i] = values
+		if err := values.setParseNames(enumType, g.CaseInsensitive); err != nil {
+			return err
+		}
+
+		if g.GenFlags {
+			if err := validateFlags(enumType, values); err != nil {
+				return err
+			}
+		}
+		if g.GenSQL && !g.SQLAsString {
+			if err := validateSQL(enumType, values); err != nil {
+				return err
+			}
+		}
+		g.typeDocs[i] = typeDoc(fAST, enumType)
+		if g.Transitions[i], err = parseTransitions(fAST, enumType, values); err != nil {
+			return err
+		}
+		if len(g.ProtoTypes) > 0 {
+			if g.Protos[i], err = g.mapProtoType(pkgs, enumType, g.ProtoTypes[i], values); err != nil {
+				return err
+			}
+		}
+		if g.ProtoOut != "" {
+			protoEnum, err := toProtoEnum(enumType, values)
+			if err != nil {
+				return err
+			}
+			g.ProtoEnums = append(g.ProtoEnums, protoEnum)
+		}
 
 		if g.DisableTraits || len(values) == 0 {
 			continue
@@ -108,6 +212,7 @@ func (g *Generate) Parse() error {
 					OwningValue:  v,
 					variableName: v.astLine.Names[j].Name,
 					value:        xprStr,
+					constValue:   pkg.TypesInfo.Types[v.astLine.Values[j]].Value,
 				})
 				sort.Sort(tDesc.Traits)
 				traits[j-1] = tDesc
@@ -127,6 +232,30 @@ func (g *Generate) Parse() error {
 	return nil
 }
 
+// DeclarationOrder returns true if values are ordered as they are declared rather than by value.
+// exposed for use in templates.
+func (g *Generate) DeclarationOrder() bool {
+	return g.Order == orderDeclaration
+}
+
+// OrderName describes the order of values in generated docs.
+// exposed for use in templates.
+func (g *Generate) OrderName() string {
+	if g.DeclarationOrder() {
+		return orderDeclaration
+	}
+	return orderValue
+}
+
+// Ordered returns the de-duplicated values in the configured order.
+// exposed for use in templates.
+func (g *Generate) Ordered(values Values) Values {
+	if g.DeclarationOrder() {
+		return values.DeclarationOrderedSet()
+	}
+	return values.ValueDeduplicatedSet()
+}
+
 // validateParsableTraits returns an error if two instances of a value of a parsable trait map to
 // different enums.
 //
@@ -161,6 +290,36 @@ func validateParsableTraits(enumType string, traits TraitDescs) error {
 	return nil
 }
 
+// validateFlags returns an error if any value of a bit-flag enum is not zero or a power of two.
+func validateFlags(enumType string, values Values) error {
+	if values.IsString() {
+		return fmt.Errorf("Enum: %s. string-backed enums cannot be bit-flag enums.", enumType)
+	}
+	for _, v := range values {
+		if v.Value&(v.Value-1) != 0 {
+			return fmt.Errorf(
+				"Enum: %s. value: %s (%s) is not a power of two; "+
+					"values of bit-flag enums must each be zero or a single bit.",
+				enumType, v.Name, v.Literal())
+		}
+	}
+	return nil
+}
+
+// validateSQL returns an error if any value of an enum stored as an integer does not fit in
+// the int64 database/sql drivers accept.
+func validateSQL(enumType string, values Values) error {
+	for _, v := range values {
+		if !v.IsString && !v.Signed && v.Value > math.MaxInt64 {
+			return fmt.Errorf(
+				"Enum: %s. value: %s (%s) does not fit in an int64 and cannot be stored in sql as an integer; "+
+					"consider the sqlAsString option.",
+				enumType, v.Name, v.Literal())
+		}
+	}
+	return nil
+}
+
 // extractTraitDescs attempts to extract trait descriptions, and does some (minor) validation in the process.
 // TraitDescs come from the first type value of an enum. Generally this is 0, but on occasion it can be
 // a negative value...
@@ -184,11 +343,11 @@ func (g *Generate) extractTraitDescs(tName string, pkgScope *types.Scope, values
 		traitName := strings.TrimPrefix(name, "_")
 		if traitName == "" || traitName == "_" {
 			return nil, fmt.Errorf(
-				"Enum: %s, value: %s (%d) trait %d has no name that can be converted "+
+				"Enum: %s, value: %s (%s) trait %d has no name that can be converted "+
 					"into a trait function; this is a violation of the genum contract for "+
 					"traits which expects the first enum (by number) to define trait names. "+
 					"If this is unexpected, consider setting the DisableTraits flag.",
-				tName, firstV.Name, firstV.Value, j,
+				tName, firstV.Name, firstV.Literal(), j,
 			)
 		}
 		tDesc := TraitDesc{
@@ -201,6 +360,7 @@ func (g *Generate) extractTraitDescs(tName string, pkgScope *types.Scope, values
 					OwningValue:  firstV,
 					variableName: name,
 					value:        v.Val().ExactString(),
+					constValue:   v.Val(),
 				},
 			},
 		}
@@ -208,30 +368,30 @@ func (g *Generate) extractTraitDescs(tName string, pkgScope *types.Scope, values
 	}
 
 	// now some validation...
-	foundWithValidValues := make(set.Set[uint64], len(values))
+	foundWithValidValues := make(set.Set[string], len(values))
 	for _, v := range values {
 		// note: we could do more here by ensuring consistent types
 		// however inconsistent types will fail a compiler after generation anyway
 		// soo... who cares.
 		if len(v.astLine.Values)-1 == len(traits) {
-			foundWithValidValues.Add(v.Value)
+			foundWithValidValues.Add(v.Literal())
 		}
 	}
 
 	for _, v := range values {
-		if !foundWithValidValues.Has(v.Value) && len(v.astLine.Values) > 1 {
+		if !foundWithValidValues.Has(v.Literal()) && len(v.astLine.Values) > 1 {
 			if len(traits) == 0 {
 				return nil, fmt.Errorf(
-					"Enum: %s. value: %s (%d) has invalid trait defintions; were trait names defined?. "+
+					"Enum: %s. value: %s (%s) has invalid trait defintions; were trait names defined?. "+
 						"Expected %d traits, found %d without well-defined duplicated value "+
 						"with expected number of traits.",
-					tName, v.Name, v.Value, len(traits), len(v.astLine.Values)-1)
+					tName, v.Name, v.Literal(), len(traits), len(v.astLine.Values)-1)
 			}
 			return nil, fmt.Errorf(
-				"Enum: %s. value: %s (%d) has inconsistent trait defintions. "+
+				"Enum: %s. value: %s (%s) has inconsistent trait defintions. "+
 					"Expected %d traits, found %d without well-defined duplicated value "+
 					"witth expected number of traits.",
-				tName, v.Name, v.Value, len(traits), len(v.astLine.Values)-1)
+				tName, v.Name, v.Literal(), len(traits), len(v.astLine.Values)-1)
 		}
 	}
 
@@ -244,7 +404,18 @@ func (g *Generate) Write() error {
 		return fmt.Errorf("no values to generate; was generate called?")
 	}
 
-	return gencommon.Write(enumTemplate, g, g.OutFile)
+	if err := gencommon.Write(enumTemplate, g, g.OutFile); err != nil {
+		return err
+	}
+	if g.ProtoOut != "" {
+		if err := g.writeProto(); err != nil {
+			return err
+		}
+	}
+	if g.SchemaOut != "" {
+		return g.writeSchema()
+	}
+	return nil
 }
 
 // processDuplicates prints duplicate warnings and selects the "primary" value(s) of traits.
@@ -253,15 +424,15 @@ func processDuplicates(values Values, traits TraitDescs, enumTypeName string) {
 		return
 	}
 
-	data := make(map[uint64]Values, len(values))
+	data := make(map[string]Values, len(values))
 	for _, v := range values {
-		duplicates, ok := data[v.Value]
+		duplicates, ok := data[v.Literal()]
 		if ok {
 			duplicates = append(duplicates, v)
 		} else {
 			duplicates = Values{v}
 		}
-		data[v.Value] = duplicates
+		data[v.Literal()] = duplicates
 	}
 
 	for _, duplicates := range data {
@@ -270,38 +441,44 @@ func processDuplicates(values Values, traits TraitDescs, enumTypeName string) {
 			continue
 		}
 		// warn about potentially unsafe duplicates.
-		log.Printf("[WARN] - Definitions `%v` of `%s` share the same value `%d`. "+
+		log.Printf("[WARN] - Definitions `%v` of `%s` share the same value `%s`. "+
 			"`%s` will be arbitrarily chosen as the primary value when stringifying enums. "+
 			"If this is undesirable, please mark values other than the intended primary "+
 			"as Deprecated.",
-			duplicates.stringList(), enumTypeName, primary.Value, primary.Name)
+			duplicates.stringList(), enumTypeName, primary.Literal(), primary.Name)
 
 		// correct any traits.
 		for i, td := range traits {
 			traits[i].Traits = slices.DeleteFunc(td.Traits, func(t TraitInstance) bool {
-				return t.OwningValue.Value == primary.Value && t.OwningValue.Name != primary.Name
+				return t.OwningValue.Literal() == primary.Literal() && t.OwningValue.Name != primary.Name
 			})
 		}
 	}
 	sort.Sort(traits)
 }
 
-func isDeprecated(fAST *ast.File, name string) bool {
-	obj := fAST.Scope.Lookup(name)
-	spec, ok := obj.Decl.(*ast.ValueSpec)
-	if !ok {
-		return false
-	}
-	if spec.Doc == nil {
-		return false
-	}
-
-	for _, comment := range spec.Doc.List {
-		trimmed := strings.TrimPrefix(comment.Text, "//")
-		trimmed = strings.TrimSpace(trimmed)
-		if strings.HasPrefix(trimmed, "Deprecated:") {
-			return true
+// nameTag returns the name and aliases declared by a `genum:"name,alias1,alias2"` comment on a value.
+// The name may be left empty to only declare aliases.
+func nameTag(spec *ast.ValueSpec) (string, []string, bool) {
+	for _, group := range []*ast.CommentGroup{spec.Doc, spec.Comment} {
+		if group == nil {
+			continue
+		}
+		for _, comment := range group.List {
+			text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+			tag, ok := reflect.StructTag(text).Lookup("genum")
+			if !ok {
+				continue
+			}
+			names := strings.Split(tag, ",")
+			aliases := make([]string, 0, len(names)-1)
+			for _, alias := range names[1:] {
+				if alias = strings.TrimSpace(alias); alias != "" {
+					aliases = append(aliases, alias)
+				}
+			}
+			return strings.TrimSpace(names[0]), aliases, true
 		}
 	}
-	return false
+	return "", nil, false
 }
@@ -0,0 +1,95 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// splitWords splits an identifier into its words, e.g. `HTTPStatusOK` => [HTTP Status OK].
+// Underscores, dashes, and spaces are treated as word separators.
+func splitWords(name string) []string {
+	runes := []rune(name)
+	words := make([]string, 0, 4)
+	start := 0
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == ' ':
+			if start < i {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+		case i > start && unicode.IsUpper(r):
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// screamingSnakeCase converts an identifier to SCREAMING_SNAKE_CASE.
+func screamingSnakeCase(name string) string {
+	return strings.ToUpper(strings.Join(splitWords(name), "_"))
+}
+
+// trimTypePrefix removes the name of an enum's type from the start of a value name,
+// e.g. `StageRunning` => `Running`. The prefix is only removed at a word boundary.
+func trimTypePrefix(name, typeName string) string {
+	trimmed, ok := strings.CutPrefix(name, typeName)
+	if !ok || trimmed == "" {
+		return name
+	}
+	if trimmed = strings.TrimLeft(trimmed, "_"); trimmed == "" || unicode.IsLower([]rune(trimmed)[0]) {
+		return name
+	}
+	return trimmed
+}
+
+// caseTransforms are the case conversions that may be applied to value names with -transform.
+var caseTransforms = map[string]func(string) string{
+	"snake": func(name string) string {
+		return strings.ToLower(strings.Join(splitWords(name), "_"))
+	},
+	"kebab": func(name string) string {
+		return strings.ToLower(strings.Join(splitWords(name), "-"))
+	},
+	"screaming": screamingSnakeCase,
+}
+
+// trimPrefixTransform is the -transform option that removes the type's name from value names.
+const trimPrefixTransform = "trimPrefix"
+
+// nameTransform returns a function that applies the given -transform options to a value name.
+// The type prefix is trimmed before the case of the name is converted.
+func nameTransform(transforms []string) (func(name, typeName string) string, error) {
+	trimPrefix := false
+	convertCase := func(name string) string { return name }
+	caseName := ""
+	for _, t := range transforms {
+		if t == trimPrefixTransform {
+			trimPrefix = true
+			continue
+		}
+		fn, ok := caseTransforms[t]
+		if !ok {
+			return nil, fmt.Errorf("unknown transform %s; expected one of snake, kebab, screaming, or trimPrefix", t)
+		} else if caseName != "" && caseName != t {
+			return nil, fmt.Errorf("transforms %s and %s cannot be combined", caseName, t)
+		}
+		caseName, convertCase = t, fn
+	}
+
+	return func(name, typeName string) string {
+		if trimPrefix {
+			name = trimTypePrefix(name, typeName)
+		}
+		return convertCase(name)
+	}, nil
+}
@@ -0,0 +1,219 @@
+package gen
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"go/constant"
+	"go/types"
+	"math"
+	"os"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ProtoMapping describes the conversion between an enum and a protoc-generated enum.
+// exposed for template use.
+type ProtoMapping struct {
+	// TypeRef is how the protoc-generated enum is referenced in generated code.
+	TypeRef string
+	// ToProto maps each distinct value of the enum to a protoc-generated value.
+	ToProto []ProtoPair
+	// FromProto maps each distinct protoc-generated value to a value of the enum.
+	FromProto []ProtoPair
+}
+
+// ProtoPair pairs an enum value with a protoc-generated value.
+type ProtoPair struct {
+	Value    Value
+	ProtoRef string
+}
+
+// ProtoEnum is an enum definition written to a .proto file.
+// exposed for template use.
+type ProtoEnum struct {
+	Name       string
+	AllowAlias bool
+	Values     []ProtoEnumValue
+}
+
+// ProtoEnumValue is a value of a ProtoEnum.
+type ProtoEnumValue struct {
+	Name         string
+	Number       int64
+	IsDeprecated bool
+}
+
+// protoValue is a value of a protoc-generated enum.
+type protoValue struct {
+	name   string // the go constant name, e.g. Stage_STAGE_RUNNING.
+	number int64
+}
+
+// splitProtoType splits a fully-qualified type (e.g. github.com/org/api/pb.Stage) into its
+// package path and type name.
+func splitProtoType(protoType string) (string, string, error) {
+	i := strings.LastIndex(protoType, ".")
+	if i <= 0 || i == len(protoType)-1 {
+		return "", "", fmt.Errorf("proto type %s must be fully-qualified, e.g. github.com/org/api/pb.Stage", protoType)
+	}
+	return protoType[:i], protoType[i+1:], nil
+}
+
+// mapProtoType maps every value of an enum to the values of a protoc-generated enum by name and
+// returns an error if any value of either enum is left unmapped.
+// Names are compared case-insensitively, ignoring underscores and the names of both types;
+// e.g. `StageRunning` of `Stage` matches `Stage_STAGE_RUNNING` of `pb.Stage`.
+func (g *Generate) mapProtoType(
+	pkgs []*packages.Package,
+	enumType string,
+	protoType string,
+	values Values,
+) (*ProtoMapping, error) {
+	pkgPath, protoTypeName, err := splitProtoType(protoType)
+	if err != nil {
+		return nil, err
+	}
+	idx := slices.IndexFunc(pkgs, func(pkg *packages.Package) bool { return pkg.PkgPath == pkgPath })
+	if idx < 0 {
+		return nil, fmt.Errorf("Enum: %s. package of proto type %s not found", enumType, protoType)
+	}
+	scope := pkgs[idx].Types.Scope()
+	typeName, ok := scope.Lookup(protoTypeName).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("Enum: %s. proto type %s not found", enumType, protoType)
+	}
+
+	protoValues := make([]protoValue, 0, len(values))
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), typeName.Type()) {
+			continue
+		}
+		number, _ := constant.Int64Val(c.Val())
+		protoValues = append(protoValues, protoValue{name: name, number: number})
+	}
+	slices.SortStableFunc(protoValues, func(a, b protoValue) int { return cmp.Compare(a.number, b.number) })
+
+	typeRef := g.Imports.ExtractTypeRef(typeName.Type())
+	constPrefix := typeRef[:strings.LastIndex(typeRef, ".")+1]
+	typeNames := []string{enumType, protoTypeName}
+	slices.SortFunc(typeNames, func(a, b string) int { return cmp.Compare(len(b), len(a)) })
+	valueKey := func(v Value) string { return protoMatchKey(v.Name, typeNames) }
+	protoKey := func(p protoValue) string {
+		return protoMatchKey(strings.TrimPrefix(p.name, protoTypeName+"_"), typeNames)
+	}
+
+	mapping := &ProtoMapping{TypeRef: typeRef}
+	primaries := values.ValueDeduplicatedSet()
+	for _, primary := range primaries {
+		// duplicates of the primary value may match too.
+		match := -1
+		for _, v := range values {
+			if v.Literal() == primary.Literal() && match < 0 {
+				match = slices.IndexFunc(protoValues, func(p protoValue) bool { return protoKey(p) == valueKey(v) })
+			}
+		}
+		if match < 0 {
+			return nil, fmt.Errorf(
+				"Enum: %s. value: %s has no matching value in proto enum %s; "+
+					"every value must map to a proto value of the same name.",
+				enumType, primary.Name, protoType)
+		}
+		mapping.ToProto = append(mapping.ToProto, ProtoPair{Value: primary, ProtoRef: constPrefix + protoValues[match].name})
+	}
+
+	for i, p := range protoValues {
+		if i > 0 && protoValues[i-1].number == p.number {
+			continue // aliases of the same proto value.
+		}
+		match := -1
+		for _, alias := range protoValues[i:] {
+			if alias.number == p.number && match < 0 {
+				match = slices.IndexFunc(values, func(v Value) bool { return valueKey(v) == protoKey(alias) })
+			}
+		}
+		if match < 0 {
+			return nil, fmt.Errorf(
+				"Enum: %s. proto value: %s of %s has no matching value; "+
+					"every proto value must map to a value of the same name.",
+				enumType, p.name, protoType)
+		}
+		primary := primaries[slices.IndexFunc(primaries, func(v Value) bool {
+			return v.Literal() == values[match].Literal()
+		})]
+		mapping.FromProto = append(mapping.FromProto, ProtoPair{Value: primary, ProtoRef: constPrefix + p.name})
+	}
+
+	return mapping, nil
+}
+
+// protoMatchKey normalizes a name for matching go and proto values; the first matching type name
+// prefix is removed.
+func protoMatchKey(name string, typeNames []string) string {
+	key := strings.ToLower(strings.Join(splitWords(name), ""))
+	for _, typeName := range typeNames {
+		prefix := strings.ToLower(strings.Join(splitWords(typeName), ""))
+		if trimmed, ok := strings.CutPrefix(key, prefix); ok && trimmed != "" {
+			return trimmed
+		}
+	}
+	return key
+}
+
+// toProtoEnum converts an enum into a proto3 enum definition.
+// Value names are prefixed with the type's name as is conventional, e.g. `StageRunning` of `Stage`
+// becomes `STAGE_RUNNING`.
+func toProtoEnum(enumType string, values Values) (ProtoEnum, error) {
+	if values.IsString() {
+		return ProtoEnum{}, fmt.Errorf("Enum: %s. string-backed enums cannot be written to a .proto file.", enumType)
+	}
+
+	prefix := screamingSnakeCase(enumType) + "_"
+	result := ProtoEnum{Name: enumType, Values: make([]ProtoEnumValue, 0, len(values))}
+	numbers := make(map[int64]bool, len(values))
+	for _, v := range values {
+		//nolint:gosec // overflow is checked below.
+		number := int64(v.Value)
+		if number < math.MinInt32 || number > math.MaxInt32 || (!v.Signed && v.Value > math.MaxInt32) {
+			return ProtoEnum{}, fmt.Errorf(
+				"Enum: %s. value: %s (%s) does not fit in a proto enum's int32.", enumType, v.Name, v.Literal())
+		}
+		result.AllowAlias = result.AllowAlias || numbers[number]
+		numbers[number] = true
+		result.Values = append(result.Values, ProtoEnumValue{
+			Name:         prefix + screamingSnakeCase(trimTypePrefix(v.Name, enumType)),
+			Number:       number,
+			IsDeprecated: v.IsDeprecated,
+		})
+	}
+	if !numbers[0] {
+		return ProtoEnum{}, fmt.Errorf("Enum: %s. proto3 enums require a zero value.", enumType)
+	}
+	// proto3 requires the zero value to be listed first.
+	slices.SortStableFunc(result.Values, func(a, b ProtoEnumValue) int {
+		switch {
+		case a.Number == 0 && b.Number != 0:
+			return -1
+		case a.Number != 0 && b.Number == 0:
+			return 1
+		}
+		return 0
+	})
+	return result, nil
+}
+
+// writeProto writes the .proto definitions of the enums to ProtoOut.
+func (g *Generate) writeProto() error {
+	if g.ProtoPackage == "" {
+		g.ProtoPackage = g.PkgName
+	}
+	var buf bytes.Buffer
+	if err := protoTemplate.Execute(&buf, g); err != nil {
+		return err
+	}
+	//nolint:gosec // generated files are not sensitive.
+	return os.WriteFile(g.ProtoOut, buf.Bytes(), 0o644)
+}
@@ -0,0 +1,185 @@
+package gen
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/constant"
+	"os"
+	"reflect"
+	"strings"
+)
+
+const (
+	schemaFormatJSONSchema = "jsonschema"
+	schemaFormatOpenAPI    = "openapi"
+)
+
+// Schema is a JSON Schema definition of an enum, compatible with OpenAPI 3.
+// Details that JSON Schema cannot attach to individual values of an enum are recorded
+// in `x-` extensions, in the same order as the values.
+type Schema struct {
+	Title            string           `json:"title,omitempty"`
+	Description      string           `json:"description,omitempty"`
+	Deprecated       bool             `json:"deprecated,omitempty"`
+	Type             string           `json:"type"`
+	Items            *Schema          `json:"items,omitempty"`
+	UniqueItems      bool             `json:"uniqueItems,omitempty"`
+	Enum             []string         `json:"enum,omitempty"`
+	EnumVarNames     []string         `json:"x-enum-varnames,omitempty"`
+	EnumDescriptions []string         `json:"x-enum-descriptions,omitempty"`
+	EnumDeprecated   []string         `json:"x-enum-deprecated,omitempty"`
+	Traits           map[string][]any `json:"x-traits,omitempty"`
+}
+
+// schema returns the JSON Schema of the i-th enum type.
+// Values are in their string form, as they are marshaled to JSON; bit-flag enums are arrays of them.
+func (g *Generate) schema(i int) Schema {
+	enumType := g.Types[i]
+	values := g.Ordered(g.Values[i])
+
+	result := Schema{
+		Type:         "string",
+		Enum:         make([]string, len(values)),
+		EnumVarNames: make([]string, len(values)),
+	}
+	hasDescriptions := false
+	for j, v := range values {
+		result.Enum[j] = v.CanonicalString()
+		result.EnumVarNames[j] = v.Name
+		if v.IsDeprecated {
+			result.EnumDeprecated = append(result.EnumDeprecated, v.CanonicalString())
+		}
+		hasDescriptions = hasDescriptions || v.Description != ""
+	}
+	if hasDescriptions {
+		result.EnumDescriptions = make([]string, len(values))
+		for j, v := range values {
+			result.EnumDescriptions[j] = v.Description
+		}
+	}
+
+	for _, trait := range g.Traits[i] {
+		if result.Traits == nil {
+			result.Traits = make(map[string][]any, len(g.Traits[i]))
+		}
+		traitValues := make([]any, len(values))
+		for _, instance := range trait.Traits {
+			for j, v := range values {
+				if v.Literal() == instance.OwningValue.Literal() {
+					traitValues[j] = constantJSON(instance.constValue)
+				}
+			}
+		}
+		result.Traits[trait.Name] = traitValues
+	}
+
+	if g.GenFlags {
+		items := result
+		result = Schema{Type: "array", Items: &items, UniqueItems: true}
+	}
+	result.Title = enumType
+	result.Description, result.Deprecated = docText(g.typeDocs[i])
+	return result
+}
+
+// JSONSchema returns the JSON Schema of the i-th enum type, quoted as a go literal.
+// exposed for use in templates.
+func (g *Generate) JSONSchema(i int) (string, error) {
+	b, err := json.MarshalIndent(g.schema(i), "", "\t")
+	if err != nil {
+		return "", err
+	}
+	return goLiteral(string(b)), nil
+}
+
+// writeSchema writes the JSON Schemas of the enums to SchemaOut.
+func (g *Generate) writeSchema() error {
+	defs := make(map[string]Schema, len(g.Types))
+	for i, enumType := range g.Types {
+		defs[enumType] = g.schema(i)
+	}
+
+	var doc any
+	switch g.SchemaFormat {
+	case schemaFormatOpenAPI:
+		doc = map[string]any{"components": map[string]any{"schemas": defs}}
+	default:
+		doc = map[string]any{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"$defs":   defs,
+		}
+	}
+
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	//nolint:gosec // generated files are not sensitive.
+	return os.WriteFile(g.SchemaOut, append(b, '\n'), 0o644)
+}
+
+// valueDescription returns the doc comment of a value, or its line comment if it has none.
+func valueDescription(spec *ast.ValueSpec) string {
+	for _, group := range []*ast.CommentGroup{spec.Doc, spec.Comment} {
+		if text, _ := docText(group); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// docText returns the text of a comment without directives, `genum:"..."` name tags, or
+// `Deprecated:` paragraphs, and whether such a paragraph was found.
+func docText(group *ast.CommentGroup) (string, bool) {
+	if group == nil {
+		return "", false
+	}
+	deprecated := false
+	paragraphs := strings.Split(group.Text(), "\n\n")
+	kept := make([]string, 0, len(paragraphs))
+	for _, paragraph := range paragraphs {
+		lines := strings.Split(paragraph, "\n")
+		keptLines := make([]string, 0, len(lines))
+		for _, line := range lines {
+			line = strings.TrimSpace(line)
+			if strings.HasPrefix(line, "Deprecated:") {
+				// the notice continues to the end of the paragraph.
+				deprecated = true
+				break
+			}
+			if _, ok := reflect.StructTag(line).Lookup("genum"); !ok {
+				keptLines = append(keptLines, line)
+			}
+		}
+		if paragraph = strings.TrimSpace(strings.Join(keptLines, "\n")); paragraph != "" {
+			kept = append(kept, paragraph)
+		}
+	}
+	return strings.Join(kept, "\n\n"), deprecated
+}
+
+// constantJSON converts a constant to a value that marshals to its JSON equivalent.
+func constantJSON(v constant.Value) any {
+	if v == nil {
+		return nil
+	}
+	switch v.Kind() {
+	case constant.Bool:
+		return constant.BoolVal(v)
+	case constant.String:
+		return constant.StringVal(v)
+	case constant.Int:
+		if i, ok := constant.Int64Val(v); ok {
+			return i
+		}
+		if u, ok := constant.Uint64Val(v); ok {
+			return u
+		}
+		return json.Number(v.ExactString())
+	case constant.Float:
+		f, _ := constant.Float64Val(v)
+		return f
+	default:
+		return v.ExactString()
+	}
+}
@@ -1,6 +1,7 @@
 package gen
 
 import (
+	"go/constant"
 	"go/types"
 
 	"github.com/drshriveer/gtools/gencommon"
@@ -244,7 +245,8 @@ func (s TraitInstances) Less(i, j int) bool {
 type TraitInstance struct {
 	OwningValue  Value
 	value        string
-	variableName string // optional; will be used if exists.
+	variableName string         // optional; will be used if exists.
+	constValue   constant.Value // the evaluated value, if known.
 }
 
 // Value safely returns a reference to a constant OR an absolute value.
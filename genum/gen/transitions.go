@@ -0,0 +1,138 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// transitionsDirective declares the transitions of an enum in the doc comment of its type, e.g.
+//
+//	//genum:transitions Pending->Running,Failed Running->Done
+const transitionsDirective = "//genum:transitions"
+
+// Transition is the set of values an enum may transition to from one of its values.
+type Transition struct {
+	From Value
+	To   Values
+}
+
+// Transitions are the allowed transitions of an enum, ordered by value.
+type Transitions []Transition
+
+// DOT returns the transitions as a Graphviz DOT graph, quoted as a go literal.
+// Every value is a node, named by its string form.
+// exposed for use in templates.
+func (t Transitions) DOT(enumType string, values Values) string {
+	sb := strings.Builder{}
+	sb.WriteString("digraph " + enumType + " {\n")
+	for _, v := range values.ValueDeduplicatedSet() {
+		sb.WriteString("\t" + strconv.Quote(v.CanonicalString()) + ";\n")
+	}
+	for _, transition := range t {
+		for _, to := range transition.To {
+			sb.WriteString("\t" + strconv.Quote(transition.From.CanonicalString()) +
+				" -> " + strconv.Quote(to.CanonicalString()) + ";\n")
+		}
+	}
+	sb.WriteString("}\n")
+
+	return goLiteral(sb.String())
+}
+
+// goLiteral quotes s as a go string literal, preferring a raw string for readability.
+func goLiteral(s string) string {
+	if strconv.CanBackquote(strings.ReplaceAll(s, "\n", "")) {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+// parseTransitions reads the transitions of an enum from the `//genum:transitions` directives
+// in the doc comment of its type. Each directive holds space-separated rules of the form
+// `From->To1,To2`, naming values by their go names. Rules for the same value are combined.
+// Values are resolved to their primary value, so duplicates may be used interchangeably.
+func parseTransitions(fAST *ast.File, enumType string, values Values) (Transitions, error) {
+	doc := typeDoc(fAST, enumType)
+	if doc == nil {
+		return nil, nil
+	}
+
+	primaries := values.ValueDeduplicatedSet()
+	lookup := func(rule, name string) (Value, error) {
+		i := slices.IndexFunc(values, func(v Value) bool { return v.Name == name })
+		if i < 0 {
+			return Value{}, fmt.Errorf(
+				"Enum: %s. transition `%s` refers to unknown value %s.", enumType, rule, name)
+		}
+		j := slices.IndexFunc(primaries, func(v Value) bool { return v.Literal() == values[i].Literal() })
+		return primaries[j], nil
+	}
+
+	result := make(Transitions, 0)
+	for _, comment := range doc.List {
+		rules, ok := strings.CutPrefix(comment.Text, transitionsDirective)
+		if !ok || (rules != "" && rules[0] != ' ' && rules[0] != '\t') {
+			continue
+		}
+		for _, rule := range strings.Fields(rules) {
+			fromName, toNames, ok := strings.Cut(rule, "->")
+			if !ok || fromName == "" || toNames == "" {
+				return nil, fmt.Errorf(
+					"Enum: %s. invalid transition `%s`; expected the form From->To1,To2.", enumType, rule)
+			}
+			from, err := lookup(rule, fromName)
+			if err != nil {
+				return nil, err
+			}
+			i := slices.IndexFunc(result, func(t Transition) bool { return t.From.Literal() == from.Literal() })
+			if i < 0 {
+				result = append(result, Transition{From: from})
+				i = len(result) - 1
+			}
+			for _, toName := range strings.Split(toNames, ",") {
+				to, err := lookup(rule, toName)
+				if err != nil {
+					return nil, err
+				}
+				if !slices.ContainsFunc(result[i].To, func(v Value) bool { return v.Literal() == to.Literal() }) {
+					result[i].To = append(result[i].To, to)
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(result, func(a, b Transition) int {
+		switch {
+		case a.From.Less(b.From):
+			return -1
+		case b.From.Less(a.From):
+			return 1
+		default:
+			return 0
+		}
+	})
+	return result, nil
+}
+
+// typeDoc returns the doc comment of a type declared in fAST.
+// The doc comment of a grouped declaration, e.g. `type ( ... )`, does not belong to any one type.
+func typeDoc(fAST *ast.File, typeName string) *ast.CommentGroup {
+	for _, decl := range fAST.Decls {
+		d, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range d.Specs {
+			if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.Name == typeName {
+				if ts.Doc != nil || len(d.Specs) > 1 {
+					return ts.Doc
+				}
+				return d.Doc
+			}
+		}
+	}
+	return nil
+}
@@ -1,7 +1,11 @@
 package gen
 
 import (
+	"cmp"
+	"fmt"
 	"go/ast"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -19,6 +23,12 @@ func (s Values) Less(i, j int) bool {
 	return s[i].Less(s[j])
 }
 
+// IsString returns true if the values belong to a string-backed enum.
+// exposed for use in templates.
+func (s Values) IsString() bool {
+	return len(s) > 0 && s[0].IsString
+}
+
 // ValueDeduplicatedSet returns a de-deduplicated set of values.
 // exposed for use in templates.
 func (s Values) ValueDeduplicatedSet() Values {
@@ -27,13 +37,13 @@ func (s Values) ValueDeduplicatedSet() Values {
 	}
 	result := make(Values, 0, len(Values{}))
 	result = append(result, s[0])
-	lastValue := s[0].Value
+	lastValue := s[0].Literal()
 	addedDeprecated := s[0].IsDeprecated
 	for i := 1; i < len(s); i++ {
 		curr := s[i]
-		if lastValue != curr.Value {
+		if lastValue != curr.Literal() {
 			result = append(result, curr)
-			lastValue = curr.Value
+			lastValue = curr.Literal()
 			addedDeprecated = curr.IsDeprecated
 		} else if addedDeprecated && !curr.IsDeprecated {
 			result[len(result)-1] = curr
@@ -42,6 +52,22 @@ func (s Values) ValueDeduplicatedSet() Values {
 	return result
 }
 
+// DeclarationOrderedSet returns a de-duplicated set of values in the order they were declared.
+// exposed for use in templates.
+func (s Values) DeclarationOrderedSet() Values {
+	result := slices.Clone(s.ValueDeduplicatedSet())
+	slices.SortStableFunc(result, func(a, b Value) int {
+		return cmp.Compare(a.Line, b.Line)
+	})
+	return result
+}
+
+// Last returns the last value.
+// exposed for use in templates.
+func (s Values) Last() Value {
+	return s[len(s)-1]
+}
+
 // getPrimary should only be called on a set of Values where the actual underlying Value
 // is the same. It will return the "primaryu" version of the duplicates
 // (the first, non-deprecated version) and a bool indicating whether the primary value is
@@ -65,6 +91,58 @@ func (s Values) getPrimary() (Value, bool) {
 	return primary, true
 }
 
+// setParseNames sets the names each value may be parsed from: its string form, its go name
+// (or value for string-backed enums) so that renamed values remain parsable, and its aliases.
+// Names are only parsed to the first value that claims them, primary values first; an error is
+// returned if a name is claimed by values that differ.
+// If caseInsensitive is set, names may not differ only by case either.
+func (s Values) setParseNames(enumType string, caseInsensitive bool) error {
+	primaries := s.ValueDeduplicatedSet()
+	ordered := make([]*Value, 0, len(s))
+	for i := range s {
+		if slices.ContainsFunc(primaries, func(p Value) bool { return p.Name == s[i].Name }) {
+			ordered = append(ordered, &s[i])
+		}
+	}
+	for i := range s {
+		if !slices.ContainsFunc(primaries, func(p Value) bool { return p.Name == s[i].Name }) {
+			ordered = append(ordered, &s[i])
+		}
+	}
+
+	claimed := make(map[string]*Value, len(s))
+	for _, v := range ordered {
+		names := []string{v.CanonicalString(), v.Name}
+		if v.IsString {
+			names[1] = v.StringValue
+		}
+		names = append(names, v.Aliases...)
+
+		v.ParseNames = nil
+		for _, name := range names {
+			key := name
+			if caseInsensitive {
+				key = strings.ToLower(name)
+			}
+			if other, ok := claimed[key]; ok {
+				if other.Literal() != v.Literal() {
+					return fmt.Errorf(
+						"Enum: %s. value: %s cannot be parsed from `%s`, which already parses to %s.",
+						enumType, v.Name, name, other.Name)
+				}
+				if other != v {
+					continue
+				}
+			}
+			claimed[key] = v
+			if !slices.Contains(v.ParseNames, name) {
+				v.ParseNames = append(v.ParseNames, name)
+			}
+		}
+	}
+	return nil
+}
+
 func (s Values) stringList() []string {
 	result := make([]string, len(s))
 	for i, v := range s {
@@ -78,13 +156,30 @@ type Value struct {
 	Name         string
 	Value        uint64
 	Signed       bool
+	IsString     bool
+	StringValue  string // set in place of Value for string-backed enums.
 	IsDeprecated bool
 	Line         int
 	astLine      *ast.ValueSpec
+
+	// Description is the value's doc comment, without deprecation notices or name tags.
+	Description string
+	// DisplayName overrides the name of the value in its string form when set.
+	DisplayName string
+	// Aliases are additional names the value may be parsed from.
+	Aliases []string
+	// ParseNames are all names the value may be parsed from, see setParseNames.
+	ParseNames []string
 }
 
 // Less is a Value sorting function.
 func (v Value) Less(vIn Value) bool {
+	if v.IsString || vIn.IsString {
+		if v.StringValue == vIn.StringValue {
+			return v.Name < vIn.Name
+		}
+		return v.StringValue < vIn.StringValue
+	}
 	if v.Signed || vIn.Signed {
 		//nolint:gosec // overflow is not a concern here.
 		v1, v2 := int64(v.Value), int64(vIn.Value)
@@ -100,7 +195,59 @@ func (v Value) Less(vIn Value) bool {
 	return v1 < v2
 }
 
-// LowerCaseName lowercase value of the name.
+// Literal returns the value as a go literal.
+func (v Value) Literal() string {
+	switch {
+	case v.IsString:
+		return strconv.Quote(v.StringValue)
+	case v.Signed:
+		//nolint:gosec // overflow is not a concern here.
+		return strconv.FormatInt(int64(v.Value), 10)
+	default:
+		return strconv.FormatUint(v.Value, 10)
+	}
+}
+
+// CanonicalString is the string form of the value: its display name if it has one, otherwise
+// its name, or its value for string-backed enums.
+func (v Value) CanonicalString() string {
+	switch {
+	case v.DisplayName != "":
+		return v.DisplayName
+	case v.IsString:
+		return v.StringValue
+	default:
+		return v.Name
+	}
+}
+
+// LowerCaseName lowercase value of the canonical string.
 func (v Value) LowerCaseName() string {
-	return strings.ToLower(v.Name)
+	return strings.ToLower(v.CanonicalString())
+}
+
+// QuotedParseNames returns the value's ParseNames as a list of go literals, e.g. `"A", "a"`.
+// exposed for use in templates.
+func (v Value) QuotedParseNames() string {
+	return quoteAll(v.ParseNames)
+}
+
+// QuotedLowerParseNames returns the distinct lowercase versions of the value's ParseNames
+// as a list of go literals. exposed for use in templates.
+func (v Value) QuotedLowerParseNames() string {
+	lower := make([]string, 0, len(v.ParseNames))
+	for _, name := range v.ParseNames {
+		if l := strings.ToLower(name); !slices.Contains(lower, l) {
+			lower = append(lower, l)
+		}
+	}
+	return quoteAll(lower)
+}
+
+func quoteAll(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = strconv.Quote(name)
+	}
+	return strings.Join(quoted, ", ")
 }
@@ -10,6 +10,7 @@ require (
 	github.com/drshriveer/gtools/set v0.0.0-20251103190437-0d41f34ed835
 	github.com/itzg/go-flagsfiller v1.12.0
 	github.com/stretchr/testify v1.9.0
+	golang.org/x/tools v0.38.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -19,5 +20,4 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/mod v0.29.0 // indirect
 	golang.org/x/sync v0.17.0 // indirect
-	golang.org/x/tools v0.38.0 // indirect
 )
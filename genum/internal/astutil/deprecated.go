@@ -0,0 +1,32 @@
+// Package astutil holds syntax helpers shared by genum's generator and linter.
+package astutil
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// IsDeprecated returns true if the value of the given name, declared in fAST, is documented
+// as `Deprecated:`.
+func IsDeprecated(fAST *ast.File, name string) bool {
+	obj := fAST.Scope.Lookup(name)
+	if obj == nil {
+		return false
+	}
+	spec, ok := obj.Decl.(*ast.ValueSpec)
+	if !ok {
+		return false
+	}
+	if spec.Doc == nil {
+		return false
+	}
+
+	for _, comment := range spec.Doc.List {
+		trimmed := strings.TrimPrefix(comment.Text, "//")
+		trimmed = strings.TrimSpace(trimmed)
+		if strings.HasPrefix(trimmed, "Deprecated:") {
+			return true
+		}
+	}
+	return false
+}
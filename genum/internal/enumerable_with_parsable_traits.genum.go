@@ -17,6 +17,17 @@ var _EnumerableWithParsableTraitsValues = []EnumerableWithParsableTraits{
 	P3,
 }
 
+func init() {
+	genum.Register(_EnumerableWithParsableTraitsValues, map[string]func(EnumerableWithParsableTraits) any{
+		"NonParsable": func(e EnumerableWithParsableTraits) any { return e.NonParsable() },
+		"OtherEnum":   func(e EnumerableWithParsableTraits) any { return e.OtherEnum() },
+		"Parsable1":   func(e EnumerableWithParsableTraits) any { return e.Parsable1() },
+		"Parsable2":   func(e EnumerableWithParsableTraits) any { return e.Parsable2() },
+		"Parsable3":   func(e EnumerableWithParsableTraits) any { return e.Parsable3() },
+		"TypedString": func(e EnumerableWithParsableTraits) any { return e.TypedString() },
+	})
+}
+
 // NonParsable returns the enum's associated trait of the same name.
 // If no trait exists for the enumeration a default value will be returned.
 func (e EnumerableWithParsableTraits) NonParsable() string {
@@ -117,7 +128,7 @@ func (e EnumerableWithParsableTraits) IsValid() bool {
 	return false
 }
 
-// Values returns a list of all potential values of this enum.
+// Values returns a list of all potential values of this enum, in value order.
 func (EnumerableWithParsableTraits) Values() []EnumerableWithParsableTraits {
 	return slices.Clone(_EnumerableWithParsableTraitsValues)
 }
@@ -214,6 +225,56 @@ func (e *EnumerableWithParsableTraits) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("unable to unmarshal EnumerableWithParsableTraits from `%v`", data)
 }
 
+// JSONSchema returns the JSON Schema of EnumerableWithParsableTraits, which is compatible with OpenAPI 3.
+func (EnumerableWithParsableTraits) JSONSchema() []byte {
+	return []byte(`{
+	"title": "EnumerableWithParsableTraits",
+	"type": "string",
+	"enum": [
+		"P1",
+		"P2",
+		"P3"
+	],
+	"x-enum-varnames": [
+		"P1",
+		"P2",
+		"P3"
+	],
+	"x-traits": {
+		"NonParsable": [
+			"non-parsable",
+			"non-parsable",
+			"non-parsable"
+		],
+		"OtherEnum": [
+			0,
+			1,
+			2
+		],
+		"Parsable1": [
+			1,
+			2,
+			3
+		],
+		"Parsable2": [
+			"1",
+			"2",
+			"3"
+		],
+		"Parsable3": [
+			3,
+			2,
+			1
+		],
+		"TypedString": [
+			"typedStr1",
+			"typedStr2",
+			"typedStr3"
+		]
+	}
+}`)
+}
+
 // MarshalText implements the encoding.TextMarshaler interface for EnumerableWithParsableTraits.
 func (e EnumerableWithParsableTraits) MarshalText() ([]byte, error) {
 	return []byte(e.String()), nil
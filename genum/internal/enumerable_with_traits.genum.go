@@ -19,6 +19,14 @@ var _EnumerableWithTraitsValues = []EnumerableWithTraits{
 	E3,
 }
 
+func init() {
+	genum.Register(_EnumerableWithTraitsValues, map[string]func(EnumerableWithTraits) any{
+		"Timeout":          func(e EnumerableWithTraits) any { return e.Timeout() },
+		"Trait":            func(e EnumerableWithTraits) any { return e.Trait() },
+		"TypedStringTrait": func(e EnumerableWithTraits) any { return e.TypedStringTrait() },
+	})
+}
+
 // Timeout returns the enum's associated trait of the same name.
 // If no trait exists for the enumeration a default value will be returned.
 func (e EnumerableWithTraits) Timeout() stupidTime.Duration {
@@ -74,7 +82,7 @@ func (e EnumerableWithTraits) IsValid() bool {
 	return false
 }
 
-// Values returns a list of all potential values of this enum.
+// Values returns a list of all potential values of this enum, in value order.
 func (EnumerableWithTraits) Values() []EnumerableWithTraits {
 	return slices.Clone(_EnumerableWithTraitsValues)
 }
@@ -161,6 +169,41 @@ func (e *EnumerableWithTraits) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("unable to unmarshal EnumerableWithTraits from `%v`", data)
 }
 
+// JSONSchema returns the JSON Schema of EnumerableWithTraits, which is compatible with OpenAPI 3.
+func (EnumerableWithTraits) JSONSchema() []byte {
+	return []byte(`{
+	"title": "EnumerableWithTraits",
+	"type": "string",
+	"enum": [
+		"E1",
+		"E2",
+		"E3"
+	],
+	"x-enum-varnames": [
+		"E1",
+		"E2",
+		"E3"
+	],
+	"x-traits": {
+		"Timeout": [
+			300000000000,
+			60000000000,
+			120000000000
+		],
+		"Trait": [
+			"trait 1",
+			"trait 2",
+			"trait 3"
+		],
+		"TypedStringTrait": [
+			"OtherType0",
+			"OtherType2",
+			"OtherType3"
+		]
+	}
+}`)
+}
+
 // MarshalText implements the encoding.TextMarshaler interface for EnumerableWithTraits.
 func (e EnumerableWithTraits) MarshalText() ([]byte, error) {
 	return []byte(e.String()), nil
@@ -209,6 +252,13 @@ var _CreaturesValues = []Creatures{
 	SeaAnemone,
 }
 
+func init() {
+	genum.Register(_CreaturesValues, map[string]func(Creatures) any{
+		"IsCreatureMammal": func(e Creatures) any { return e.IsCreatureMammal() },
+		"NumCreatureLegs":  func(e Creatures) any { return e.NumCreatureLegs() },
+	})
+}
+
 // IsCreatureMammal returns the enum's associated trait of the same name.
 // If no trait exists for the enumeration a default value will be returned.
 func (e Creatures) IsCreatureMammal() bool {
@@ -261,7 +311,7 @@ func (e Creatures) IsValid() bool {
 	return false
 }
 
-// Values returns a list of all potential values of this enum.
+// Values returns a list of all potential values of this enum, in value order.
 func (Creatures) Values() []Creatures {
 	return slices.Clone(_CreaturesValues)
 }
@@ -384,6 +434,52 @@ func (e *Creatures) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("unable to unmarshal Creatures from `%v`", data)
 }
 
+// JSONSchema returns the JSON Schema of Creatures, which is compatible with OpenAPI 3.
+func (Creatures) JSONSchema() []byte {
+	return []byte(`{
+	"title": "Creatures",
+	"type": "string",
+	"enum": [
+		"NotCreature",
+		"Cat",
+		"Dog",
+		"Ant",
+		"Spider",
+		"Human",
+		"SeaAnemone"
+	],
+	"x-enum-varnames": [
+		"NotCreature",
+		"Cat",
+		"Dog",
+		"Ant",
+		"Spider",
+		"Human",
+		"SeaAnemone"
+	],
+	"x-traits": {
+		"IsCreatureMammal": [
+			false,
+			true,
+			true,
+			false,
+			false,
+			true,
+			null
+		],
+		"NumCreatureLegs": [
+			0,
+			4,
+			4,
+			6,
+			8,
+			2,
+			null
+		]
+	}
+}`)
+}
+
 // MarshalText implements the encoding.TextMarshaler interface for Creatures.
 func (e Creatures) MarshalText() ([]byte, error) {
 	return []byte(e.String()), nil
@@ -428,6 +524,12 @@ var _EnumWithPackageImportsValues = []EnumWithPackageImports{
 	EnumWithPackageImports2,
 }
 
+func init() {
+	genum.Register(_EnumWithPackageImportsValues, map[string]func(EnumWithPackageImports) any{
+		"Kind": func(e EnumWithPackageImports) any { return e.Kind() },
+	})
+}
+
 // Kind returns the enum's associated trait of the same name.
 // If no trait exists for the enumeration a default value will be returned.
 func (e EnumWithPackageImports) Kind() reflect.Kind {
@@ -453,7 +555,7 @@ func (e EnumWithPackageImports) IsValid() bool {
 	return false
 }
 
-// Values returns a list of all potential values of this enum.
+// Values returns a list of all potential values of this enum, in value order.
 func (EnumWithPackageImports) Values() []EnumWithPackageImports {
 	return slices.Clone(_EnumWithPackageImportsValues)
 }
@@ -540,6 +642,31 @@ func (e *EnumWithPackageImports) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("unable to unmarshal EnumWithPackageImports from `%v`", data)
 }
 
+// JSONSchema returns the JSON Schema of EnumWithPackageImports, which is compatible with OpenAPI 3.
+func (EnumWithPackageImports) JSONSchema() []byte {
+	return []byte(`{
+	"title": "EnumWithPackageImports",
+	"type": "string",
+	"enum": [
+		"EnumWithPackageImports0",
+		"EnumWithPackageImports1",
+		"EnumWithPackageImports2"
+	],
+	"x-enum-varnames": [
+		"EnumWithPackageImports0",
+		"EnumWithPackageImports1",
+		"EnumWithPackageImports2"
+	],
+	"x-traits": {
+		"Kind": [
+			24,
+			11,
+			1
+		]
+	}
+}`)
+}
+
 // MarshalText implements the encoding.TextMarshaler interface for EnumWithPackageImports.
 func (e EnumWithPackageImports) MarshalText() ([]byte, error) {
 	return []byte(e.String()), nil
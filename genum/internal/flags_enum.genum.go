@@ -0,0 +1,265 @@
+// Code generated by genum DO NOT EDIT.
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/drshriveer/gtools/genum"
+	"gopkg.in/yaml.v3"
+)
+
+var _PermissionValues = []Permission{
+	NoPermission,
+	Read,
+	Write,
+	Execute,
+}
+
+func init() {
+	genum.Register(_PermissionValues, nil)
+}
+
+// IsValid returns true if only defined flags are set.
+func (e Permission) IsValid() bool {
+	for _, v := range _PermissionValues {
+		e &^= v
+	}
+	return e == 0
+}
+
+// Has returns true if all of the provided flags are set.
+func (e Permission) Has(flags Permission) bool {
+	return e&flags == flags
+}
+
+// Set returns a copy of the enum with the provided flags set.
+func (e Permission) Set(flags Permission) Permission {
+	return e | flags
+}
+
+// Clear returns a copy of the enum with the provided flags cleared.
+func (e Permission) Clear(flags Permission) Permission {
+	return e &^ flags
+}
+
+// Toggle returns a copy of the enum with the provided flags flipped.
+func (e Permission) Toggle(flags Permission) Permission {
+	return e ^ flags
+}
+
+// Values returns the individual flags set in this enum, in ascending order.
+func (e Permission) Values() []Permission {
+	result := make([]Permission, 0, len(_PermissionValues))
+	for _, v := range _PermissionValues {
+		if v != 0 && e&v == v {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// flagNames returns the names of the flags set in this enum; undefined bits are
+// grouped into a single trailing entry.
+func (e Permission) flagNames() []string {
+	result := make([]string, 0, len(_PermissionValues))
+	remaining := e
+	for _, v := range e.Values() {
+		result = append(result, v.String())
+		remaining &^= v
+	}
+	if remaining != 0 {
+		result = append(result, fmt.Sprintf("UndefinedPermission:%d", remaining))
+	}
+	return result
+}
+
+// All returns an iterator over all potential values of this enum, in value order.
+func (Permission) All() iter.Seq[Permission] {
+	return slices.Values(_PermissionValues)
+}
+
+// StringValues returns a list of all potential values of this enum as strings.
+// Note: This does not return duplicates.
+func (Permission) StringValues() []string {
+	return []string{
+		"NoPermission",
+		"Read",
+		"Write",
+		"Execute",
+	}
+}
+
+// String returns a string representation of this enum.
+// Note: in the case of duplicate values only the first alphabetical definition will be choosen.
+// Combined flags are joined by `|`, e.g. `A|B`.
+func (e Permission) String() string {
+	switch e {
+	case NoPermission:
+		return "NoPermission"
+	case Read:
+		return "Read"
+	case Write:
+		return "Write"
+	case Execute:
+		return "Execute"
+	default:
+		return strings.Join(e.flagNames(), "|")
+	}
+}
+
+// ParseString will return a value as defined in string form.
+func (e Permission) ParseString(text string) (Permission, error) {
+	return ParsePermission(text)
+}
+
+// ParsePermission will attempt to parse the value of a Permission from either its string form
+// or any value of a trait flagged with the --parsableByTrait flag.
+// Combined flags may be parsed from names joined by `|`, e.g. `A|B`.
+func ParsePermission(input any) (Permission, error) {
+	switch input {
+	case "NoPermission":
+		return NoPermission, nil
+	case "Read":
+		return Read, nil
+	case "Write":
+		return Write, nil
+	case "Execute":
+		return Execute, nil
+	default:
+		if text, ok := input.(string); ok && strings.TrimSpace(text) == "" {
+			// no flags set.
+			return 0, nil
+		} else if ok && strings.Contains(text, "|") {
+			var result Permission
+			for _, name := range strings.Split(text, "|") {
+				v, err := ParsePermission(strings.TrimSpace(name))
+				if err != nil {
+					return 0, err
+				}
+				result |= v
+			}
+			return result, nil
+		}
+		return 0, fmt.Errorf("`%+v` could not be parsed to enum of type Permission", input)
+	}
+}
+
+// ParseGeneric calls TypedEnum.Parse but returns the result
+// in the generic genum.Enum interface. Which is useful when you are only able to work with
+// the un-typed interface.
+func (e Permission) ParseGeneric(input any) (genum.Enum, error) {
+	return ParsePermission(input)
+}
+
+// MarshalJSON implements the json.Marshaler interface for Permission.
+// Flags are marshaled as an array of their names.
+func (e Permission) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.flagNames())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Permission.
+// Flags are unmarshaled from an array of their names or a string of names joined by `|`.
+func (e *Permission) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err == nil {
+		var result Permission
+		for _, name := range names {
+			v, err := ParsePermission(name)
+			if err != nil {
+				return err
+			}
+			result |= v
+		}
+		*e = result
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		var err error
+		*e, err = ParsePermission(s)
+		return err
+	}
+
+	return fmt.Errorf("unable to unmarshal Permission from `%v`", data)
+}
+
+// JSONSchema returns the JSON Schema of Permission, which is compatible with OpenAPI 3.
+func (Permission) JSONSchema() []byte {
+	return []byte(`{
+	"title": "Permission",
+	"description": "Permission is a bit-flag enum.",
+	"type": "array",
+	"items": {
+		"type": "string",
+		"enum": [
+			"NoPermission",
+			"Read",
+			"Write",
+			"Execute"
+		],
+		"x-enum-varnames": [
+			"NoPermission",
+			"Read",
+			"Write",
+			"Execute"
+		]
+	},
+	"uniqueItems": true
+}`)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for Permission.
+func (e Permission) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for Permission.
+func (e *Permission) UnmarshalText(text []byte) error {
+	s := string(text)
+	var err error
+	*e, err = ParsePermission(s)
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("unable to unmarshal Permission from `%s`", s)
+}
+
+// MarshalYAML implements a YAML Marshaler for Permission.
+// Flags are marshaled as a sequence of their names.
+func (e Permission) MarshalYAML() (any, error) {
+	return e.flagNames(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for Permission.
+// Flags are unmarshaled from a sequence of their names or a string of names joined by `|`.
+func (e *Permission) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.SequenceNode {
+		var result Permission
+		for _, item := range value.Content {
+			v, err := ParsePermission(item.Value)
+			if err != nil {
+				return err
+			}
+			result |= v
+		}
+		*e = result
+		return nil
+	}
+
+	var err error
+	*e, err = ParsePermission(value.Value)
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("unable to unmarshal Permission from yaml `%s`", value.Value)
+}
+
+// IsEnum implements an empty function required to implement Enum.
+func (Permission) IsEnum() {}
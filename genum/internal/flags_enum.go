@@ -0,0 +1,23 @@
+//nolint:revive // test only
+package internal
+
+//go:generate genum -types=Permission -flags -ordering
+
+// Permission is a bit-flag enum.
+type Permission uint8
+
+const (
+	NoPermission Permission = 0
+	Read         Permission = 1 << (iota - 1)
+	Write
+	Execute
+)
+
+// NotFlags is not a valid bit-flag enum because Both is neither zero nor a single bit.
+type NotFlags int
+
+const (
+	First NotFlags = 1 << iota
+	Second
+	Both = First | Second
+)
@@ -0,0 +1,157 @@
+package internal_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/drshriveer/gtools/genum"
+	"github.com/drshriveer/gtools/genum/gen"
+	"github.com/drshriveer/gtools/genum/internal"
+)
+
+func TestFlagsEnumGeneration(t *testing.T) {
+	t.Parallel()
+	generator := gen.Generate{
+		InFile:   "./flags_enum.go",
+		OutFile:  "./flags_enum.genum.go",
+		Types:    []string{"Permission"},
+		GenFlags: true,
+	}
+	require.NoError(t, generator.Parse())
+
+	generator.Types = []string{"NotFlags"}
+	assert.ErrorContains(t, generator.Parse(), "value: Both (3) is not a power of two")
+}
+
+func TestPermission(t *testing.T) {
+	t.Parallel()
+	assert.Implements(t, (*genum.TypedEnum[internal.Permission])(nil), internal.Read)
+
+	tests := []struct {
+		enum    internal.Permission
+		sName   string
+		json    string
+		yaml    string
+		values  []internal.Permission
+		invalid bool
+	}{
+		{
+			enum:   internal.NoPermission,
+			sName:  "NoPermission",
+			json:   `[]`,
+			yaml:   "[]\n",
+			values: []internal.Permission{},
+		},
+		{
+			enum:   internal.Write,
+			sName:  "Write",
+			json:   `["Write"]`,
+			yaml:   "- Write\n",
+			values: []internal.Permission{internal.Write},
+		},
+		{
+			enum:   internal.Read | internal.Write,
+			sName:  "Read|Write",
+			json:   `["Read","Write"]`,
+			yaml:   "- Read\n- Write\n",
+			values: []internal.Permission{internal.Read, internal.Write},
+		},
+		{
+			enum:   internal.Read | internal.Write | internal.Execute,
+			sName:  "Read|Write|Execute",
+			json:   `["Read","Write","Execute"]`,
+			yaml:   "- Read\n- Write\n- Execute\n",
+			values: []internal.Permission{internal.Read, internal.Write, internal.Execute},
+		},
+		{
+			enum:    internal.Read | internal.Permission(16) | internal.Permission(32),
+			sName:   "Read|UndefinedPermission:48",
+			values:  []internal.Permission{internal.Read},
+			invalid: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.sName, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.sName, test.enum.String())
+			assert.Equal(t, test.values, test.enum.Values())
+			if test.invalid {
+				assert.False(t, test.enum.IsValid())
+				_, err := internal.ParsePermission(test.sName)
+				assert.Error(t, err)
+				return
+			}
+
+			assert.True(t, test.enum.IsValid())
+			parsed, err := internal.ParsePermission(test.sName)
+			require.NoError(t, err)
+			assert.Equal(t, test.enum, parsed)
+
+			bytes, err := json.Marshal(test.enum)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.json, string(bytes))
+			var fromJSON internal.Permission
+			require.NoError(t, json.Unmarshal(bytes, &fromJSON))
+			assert.Equal(t, test.enum, fromJSON)
+
+			bytes, err = yaml.Marshal(test.enum)
+			require.NoError(t, err)
+			assert.Equal(t, test.yaml, string(bytes))
+			var fromYAML internal.Permission
+			require.NoError(t, yaml.Unmarshal(bytes, &fromYAML))
+			assert.Equal(t, test.enum, fromYAML)
+
+			bytes, err = test.enum.MarshalText()
+			require.NoError(t, err)
+			assert.Equal(t, test.sName, string(bytes))
+			var fromText internal.Permission
+			require.NoError(t, fromText.UnmarshalText(bytes))
+			assert.Equal(t, test.enum, fromText)
+		})
+	}
+}
+
+func TestPermission_Flags(t *testing.T) {
+	t.Parallel()
+	p := internal.Read
+	assert.True(t, p.Has(internal.Read))
+	assert.False(t, p.Has(internal.Read|internal.Write))
+
+	p = p.Set(internal.Write | internal.Execute)
+	assert.Equal(t, internal.Read|internal.Write|internal.Execute, p)
+	assert.True(t, p.Has(internal.Read|internal.Write))
+
+	p = p.Clear(internal.Read)
+	assert.Equal(t, internal.Write|internal.Execute, p)
+
+	p = p.Toggle(internal.Read | internal.Write)
+	assert.Equal(t, internal.Read|internal.Execute, p)
+}
+
+func TestPermission_Parse(t *testing.T) {
+	t.Parallel()
+	parsed, err := internal.ParsePermission(" Execute | Read ")
+	require.NoError(t, err)
+	assert.Equal(t, internal.Read|internal.Execute, parsed)
+
+	parsed, err = internal.ParsePermission("")
+	require.NoError(t, err)
+	assert.Equal(t, internal.NoPermission, parsed)
+
+	_, err = internal.ParsePermission("Read|Delete")
+	assert.ErrorContains(t, err, "`Delete` could not be parsed to enum of type Permission")
+
+	var fromJSON internal.Permission
+	require.NoError(t, json.Unmarshal([]byte(`"Read|Write"`), &fromJSON))
+	assert.Equal(t, internal.Read|internal.Write, fromJSON)
+	assert.Error(t, json.Unmarshal([]byte(`["Read","Delete"]`), &fromJSON))
+
+	var fromYAML internal.Permission
+	require.NoError(t, yaml.Unmarshal([]byte("Write|Execute"), &fromYAML))
+	assert.Equal(t, internal.Write|internal.Execute, fromYAML)
+	assert.Error(t, yaml.Unmarshal([]byte("[Read, Delete]"), &fromYAML))
+}
@@ -0,0 +1,196 @@
+// Code generated by genum DO NOT EDIT.
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/drshriveer/gtools/genum"
+	"gopkg.in/yaml.v3"
+)
+
+var _TaskStateValues = []TaskState{
+	TaskStateNotStarted,
+	TaskStateInProgress,
+	TaskStateDone,
+	TaskStateBlocked,
+}
+
+func init() {
+	genum.Register(_TaskStateValues, nil)
+}
+
+// IsValid returns true if the enum value is, in fact, valid.
+func (e TaskState) IsValid() bool {
+	for _, v := range _TaskStateValues {
+		if v == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Values returns a list of all potential values of this enum, in value order.
+func (TaskState) Values() []TaskState {
+	return slices.Clone(_TaskStateValues)
+}
+
+// StringValues returns a list of all potential values of this enum as strings.
+// Note: This does not return duplicates.
+func (TaskState) StringValues() []string {
+	return []string{
+		"not-started",
+		"wip",
+		"done",
+		"blocked-on-review",
+	}
+}
+
+// String returns a string representation of this enum.
+// Note: in the case of duplicate values only the first alphabetical definition will be choosen.
+func (e TaskState) String() string {
+	switch e {
+	case TaskStateNotStarted:
+		return "not-started"
+	case TaskStateInProgress:
+		return "wip"
+	case TaskStateDone:
+		return "done"
+	case TaskStateBlocked:
+		return "blocked-on-review"
+	default:
+		return fmt.Sprintf("UndefinedTaskState:%d", e)
+	}
+}
+
+// ParseString will return a value as defined in string form.
+func (e TaskState) ParseString(text string) (TaskState, error) {
+	return ParseTaskState(text)
+}
+
+// ParseTaskState will attempt to parse the value of a TaskState from either its string form
+// or any value of a trait flagged with the --parsableByTrait flag.
+func ParseTaskState(input any) (TaskState, error) {
+	switch input {
+	case "not-started", "TaskStateNotStarted":
+		return TaskStateNotStarted, nil
+	case "wip", "TaskStateInProgress", "started", "running":
+		return TaskStateInProgress, nil
+	case "complete", "TaskStateComplete":
+		return TaskStateComplete, nil
+	case "done", "TaskStateDone", "finished":
+		return TaskStateDone, nil
+	case "blocked-on-review", "TaskStateBlocked":
+		return TaskStateBlocked, nil
+	default:
+		if text, ok := input.(string); ok {
+			switch strings.ToLower(text) {
+			case "not-started", "taskstatenotstarted":
+				return TaskStateNotStarted, nil
+			case "wip", "taskstateinprogress", "started", "running":
+				return TaskStateInProgress, nil
+			case "complete", "taskstatecomplete":
+				return TaskStateComplete, nil
+			case "done", "taskstatedone", "finished":
+				return TaskStateDone, nil
+			case "blocked-on-review", "taskstateblocked":
+				return TaskStateBlocked, nil
+			}
+		}
+		return 0, fmt.Errorf("`%+v` could not be parsed to enum of type TaskState", input)
+	}
+}
+
+// ParseGeneric calls TypedEnum.Parse but returns the result
+// in the generic genum.Enum interface. Which is useful when you are only able to work with
+// the un-typed interface.
+func (e TaskState) ParseGeneric(input any) (genum.Enum, error) {
+	return ParseTaskState(input)
+}
+
+// MarshalJSON implements the json.Marshaler interface for TaskState.
+func (e TaskState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for TaskState.
+func (e *TaskState) UnmarshalJSON(data []byte) error {
+	// We always support strings.
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		var err error
+		*e, err = ParseTaskState(s)
+		if err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unable to unmarshal TaskState from `%v`", data)
+}
+
+// JSONSchema returns the JSON Schema of TaskState, which is compatible with OpenAPI 3.
+func (TaskState) JSONSchema() []byte {
+	return []byte(`{
+	"title": "TaskState",
+	"description": "TaskState has values renamed by the kebab-case and trimPrefix transforms, or by comments.",
+	"type": "string",
+	"enum": [
+		"not-started",
+		"wip",
+		"done",
+		"blocked-on-review"
+	],
+	"x-enum-varnames": [
+		"TaskStateNotStarted",
+		"TaskStateInProgress",
+		"TaskStateDone",
+		"TaskStateBlocked"
+	],
+	"x-enum-descriptions": [
+		"",
+		"",
+		"",
+		"TaskStateBlocked is waiting on something else."
+	]
+}`)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for TaskState.
+func (e TaskState) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for TaskState.
+func (e *TaskState) UnmarshalText(text []byte) error {
+	s := string(text)
+	var err error
+	*e, err = ParseTaskState(s)
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("unable to unmarshal TaskState from `%s`", s)
+}
+
+// MarshalYAML implements a YAML Marshaler for TaskState.
+func (e TaskState) MarshalYAML() (any, error) {
+	return e.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for TaskState.
+func (e *TaskState) UnmarshalYAML(value *yaml.Node) error {
+	var err error
+
+	// first try and parse as a string
+	*e, err = ParseTaskState(value.Value)
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("unable to unmarshal TaskState from yaml `%s`", value.Value)
+}
+
+// IsEnum implements an empty function required to implement Enum.
+func (TaskState) IsEnum() {}
@@ -0,0 +1,28 @@
+//nolint:revive // test only
+package internal
+
+//go:generate genum -types=TaskState -transform=trimPrefix,kebab -caseInsensitive
+
+// TaskState has values renamed by the kebab-case and trimPrefix transforms, or by comments.
+type TaskState int
+
+const (
+	TaskStateNotStarted TaskState = iota
+	TaskStateInProgress           // genum:"wip,started,running"
+	TaskStateDone                 // genum:",finished"
+
+	// TaskStateBlocked is waiting on something else.
+	// genum:"blocked-on-review"
+	TaskStateBlocked
+
+	// Deprecated: use TaskStateDone.
+	TaskStateComplete = TaskStateDone
+)
+
+// ConflictingNames cannot be generated because `a` would parse to two values.
+type ConflictingNames int
+
+const (
+	ConflictA ConflictingNames = iota // genum:"a"
+	ConflictB                         // genum:"b,a"
+)
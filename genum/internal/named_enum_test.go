@@ -0,0 +1,110 @@
+package internal_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/genum/gen"
+	"github.com/drshriveer/gtools/genum/internal"
+)
+
+func TestNamedEnumGeneration(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		transform   []string
+		expected    []string
+		expectedErr string
+	}{
+		{
+			expected: []string{"TaskStateNotStarted", "wip", "TaskStateComplete", "TaskStateDone", "blocked-on-review"},
+		},
+		{
+			transform: []string{"snake"},
+			expected:  []string{"task_state_not_started", "wip", "task_state_complete", "task_state_done", "blocked-on-review"},
+		},
+		{
+			transform: []string{"screaming", "trimPrefix"},
+			expected:  []string{"NOT_STARTED", "wip", "COMPLETE", "DONE", "blocked-on-review"},
+		},
+		{
+			transform: []string{"trimPrefix"},
+			expected:  []string{"NotStarted", "wip", "Complete", "Done", "blocked-on-review"},
+		},
+		{
+			transform:   []string{"camel"},
+			expectedErr: "unknown transform camel",
+		},
+		{
+			transform:   []string{"snake", "kebab"},
+			expectedErr: "transforms snake and kebab cannot be combined",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.expectedErr+fmt.Sprint(test.transform), func(t *testing.T) {
+			t.Parallel()
+			generator := gen.Generate{
+				InFile:    "./named_enum.go",
+				OutFile:   "./named_enum.genum.go",
+				Types:     []string{"TaskState"},
+				Transform: test.transform,
+			}
+			err := generator.Parse()
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(test.expected))
+			for _, v := range generator.Values[0] {
+				names = append(names, v.CanonicalString())
+			}
+			assert.Equal(t, test.expected, names)
+		})
+	}
+
+	generator := gen.Generate{InFile: "./named_enum.go", Types: []string{"ConflictingNames"}}
+	assert.ErrorContains(t, generator.Parse(),
+		"value: ConflictB cannot be parsed from `a`, which already parses to ConflictA")
+}
+
+func TestTaskState(t *testing.T) {
+	t.Parallel()
+	tests := []enumTest[internal.TaskState]{
+		{enum: internal.TaskStateNotStarted, sName: "not-started"},
+		{enum: internal.TaskStateInProgress, sName: "wip"},
+		{enum: internal.TaskStateDone, sName: "done"},
+		{enum: internal.TaskStateComplete, sName: "done", duplicateDefinition: true},
+		{enum: internal.TaskStateBlocked, sName: "blocked-on-review"},
+		{enum: internal.TaskState(9), invalid: true},
+	}
+
+	testRunner[internal.TaskState](t, tests)
+}
+
+func TestTaskState_Parse(t *testing.T) {
+	t.Parallel()
+	tests := map[string]internal.TaskState{
+		"TaskStateNotStarted": internal.TaskStateNotStarted,
+		"started":             internal.TaskStateInProgress,
+		"Running":             internal.TaskStateInProgress,
+		"TaskStateInProgress": internal.TaskStateInProgress,
+		"finished":            internal.TaskStateDone,
+		"complete":            internal.TaskStateDone,
+		"BLOCKED-ON-REVIEW":   internal.TaskStateBlocked,
+	}
+	for input, expected := range tests {
+		parsed, err := internal.ParseTaskState(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, parsed, input)
+	}
+
+	var fromText internal.TaskState
+	require.NoError(t, fromText.UnmarshalText([]byte("TaskStateBlocked")))
+	assert.Equal(t, internal.TaskStateBlocked, fromText)
+
+	_, err := internal.ParseTaskState("in-progress")
+	assert.Error(t, err)
+}
@@ -0,0 +1,18 @@
+//nolint:revive // test only
+package internal
+
+//go:generate genum -types=Severity -order=declaration -ordering
+
+// Severity is declared in a different order than its values.
+type Severity int
+
+const (
+	SeverityDebug   Severity = 10
+	SeverityInfo    Severity = 20
+	SeverityFatal   Severity = 0
+	SeverityWarning Severity = 30
+
+	// Deprecated: use SeverityWarning.
+	SeverityWarn           = SeverityWarning
+	SeverityError Severity = 40
+)
@@ -0,0 +1,99 @@
+package internal_test
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/genum/gen"
+	"github.com/drshriveer/gtools/genum/internal"
+)
+
+func TestOrderedEnumGeneration(t *testing.T) {
+	t.Parallel()
+	generator := gen.Generate{
+		InFile: "./ordered_enum.go",
+		Types:  []string{"Severity"},
+		Order:  "alphabetical",
+	}
+	assert.ErrorContains(t, generator.Parse(), "unknown order alphabetical")
+}
+
+func TestDeclarationOrder(t *testing.T) {
+	t.Parallel()
+	declared := []internal.Severity{
+		internal.SeverityDebug,
+		internal.SeverityInfo,
+		internal.SeverityFatal,
+		internal.SeverityWarning,
+		internal.SeverityError,
+	}
+	assert.Equal(t, declared, internal.SeverityInfo.Values())
+	assert.Equal(t, declared, slices.Collect(internal.SeverityInfo.All()))
+	assert.Equal(t, internal.SeverityDebug, internal.Severity(0).Min())
+	assert.Equal(t, internal.SeverityError, internal.Severity(0).Max())
+
+	for i, s := range declared {
+		assert.Equal(t, i, s.Ordinal())
+	}
+	assert.Equal(t, 3, internal.SeverityWarn.Ordinal(), "duplicates share an ordinal")
+	assert.Equal(t, -1, internal.Severity(5).Ordinal())
+
+	next, ok := internal.SeverityInfo.Next()
+	assert.True(t, ok)
+	assert.Equal(t, internal.SeverityFatal, next)
+	_, ok = internal.Severity(0).Max().Next()
+	assert.False(t, ok)
+	_, ok = internal.Severity(5).Next()
+	assert.False(t, ok)
+
+	prev, ok := internal.SeverityFatal.Prev()
+	assert.True(t, ok)
+	assert.Equal(t, internal.SeverityInfo, prev)
+	_, ok = internal.Severity(0).Min().Prev()
+	assert.False(t, ok)
+
+	assert.Equal(t, -1, internal.SeverityInfo.Compare(internal.SeverityFatal))
+	assert.Equal(t, 0, internal.SeverityWarn.Compare(internal.SeverityWarning))
+	assert.Equal(t, 1, internal.SeverityFatal.Compare(internal.SeverityDebug))
+	assert.Equal(t, -1, internal.Severity(5).Compare(internal.Severity(0).Min()), "invalid values come first")
+
+	sorted := []internal.Severity{internal.SeverityError, internal.SeverityFatal, internal.SeverityDebug}
+	slices.SortFunc(sorted, internal.Severity.Compare)
+	assert.Equal(t, []internal.Severity{internal.SeverityDebug, internal.SeverityFatal, internal.SeverityError}, sorted)
+}
+
+func TestValueOrder(t *testing.T) {
+	t.Parallel()
+	values := slices.Collect(internal.Enum3Value5.All())
+	require.Len(t, values, 16)
+	assert.Equal(t, values, internal.Enum3Value5.Values())
+	assert.True(t, slices.IsSorted(values))
+	assert.Equal(t, internal.Enum3Value0, internal.MyEnum3(0).Min())
+	assert.Equal(t, internal.Enum3Value16, internal.MyEnum3(0).Max())
+
+	assert.Equal(t, 14, internal.Enum3Value15.Ordinal())
+	assert.Equal(t, -1, internal.MyEnum3(14).Ordinal())
+	next, ok := internal.Enum3Value13.Next()
+	assert.True(t, ok)
+	assert.Equal(t, internal.Enum3Value15, next, "skips missing values")
+	assert.Equal(t, 1, internal.Enum3Value16.Compare(internal.Enum3Value2))
+
+	assert.Equal(t, internal.Enum1IntentionallyNegative, internal.MyEnum(0).Min())
+	assert.Equal(t,
+		[]internal.Permission{internal.NoPermission, internal.Read, internal.Write, internal.Execute},
+		slices.Collect(internal.Read.All()))
+}
+
+func TestOrderingIsOptIn(t *testing.T) {
+	t.Parallel()
+	for _, name := range []string{"Min", "Max", "Ordinal", "Next", "Prev", "Compare", "All"} {
+		_, ok := reflect.TypeOf(internal.Red).MethodByName(name)
+		assert.False(t, ok, "%s must only be generated with -ordering", name)
+	}
+	_, ok := reflect.TypeOf(internal.Read).MethodByName("Min")
+	assert.False(t, ok, "flags enums only generate All")
+}
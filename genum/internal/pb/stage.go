@@ -0,0 +1,16 @@
+// Package pb stands in for protoc-generated code in tests; it mirrors the enum declarations
+// protoc-gen-go generates without depending on the protobuf runtime.
+//
+//nolint:revive,stylecheck // mirrors generated code.
+package pb
+
+type Stage int32
+
+const (
+	Stage_STAGE_UNSPECIFIED Stage = 0
+	Stage_STAGE_PENDING     Stage = 10
+	Stage_STAGE_RUNNING     Stage = 20
+	Stage_STAGE_DONE        Stage = 30
+	// an alias of STAGE_DONE.
+	Stage_STAGE_FINISHED Stage = 30
+)
@@ -0,0 +1,38 @@
+//nolint:revive // test only
+package internal
+
+//go:generate genum -types=Stage -protoTypes=github.com/drshriveer/gtools/genum/internal/pb.Stage -protoOut=proto_enum.proto
+
+// Stage is converted to and from pb.Stage; its values are numbered differently to show that
+// values are mapped by name.
+type Stage int
+
+const (
+	StageUnspecified Stage = iota
+	StagePending
+	StageRunning
+	StageDone
+
+	// Deprecated: use StageDone.
+	StageFinished = StageDone
+)
+
+// Phase has a value that pb.Stage does not.
+type Phase int
+
+const (
+	PhaseUnspecified Phase = iota
+	PhasePending
+	PhaseRunning
+	PhaseDone
+	PhaseArchived
+)
+
+// Step is missing a value that pb.Stage has.
+type Step int
+
+const (
+	StepUnspecified Step = iota
+	StepPending
+	StepRunning
+)
@@ -0,0 +1,112 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/genum/gen"
+	"github.com/drshriveer/gtools/genum/internal"
+	"github.com/drshriveer/gtools/genum/internal/pb"
+)
+
+func TestProtoEnumGeneration(t *testing.T) {
+	t.Parallel()
+	const protoType = "github.com/drshriveer/gtools/genum/internal/pb.Stage"
+	protoOut := filepath.Join(t.TempDir(), "stage.proto")
+	generator := gen.Generate{
+		InFile:       "./proto_enum.go",
+		OutFile:      filepath.Join(t.TempDir(), "proto_enum.genum.go"),
+		Types:        []string{"Stage"},
+		ProtoTypes:   []string{protoType},
+		ProtoOut:     protoOut,
+		ProtoPackage: "test.v1",
+	}
+	require.NoError(t, generator.Parse())
+	require.NoError(t, generator.Write())
+	proto, err := os.ReadFile(protoOut)
+	require.NoError(t, err)
+	expected, err := os.ReadFile("./proto_enum.proto")
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(string(expected), "package internal;", "package test.v1;", 1), string(proto))
+
+	tests := []struct {
+		description string
+		types       []string
+		protoTypes  []string
+		expectedErr string
+	}{
+		{
+			description: "extra value",
+			types:       []string{"Phase"},
+			protoTypes:  []string{protoType},
+			expectedErr: "value: PhaseArchived has no matching value in proto enum " + protoType,
+		},
+		{
+			description: "missing value",
+			types:       []string{"Step"},
+			protoTypes:  []string{protoType},
+			expectedErr: "proto value: Stage_STAGE_DONE of " + protoType + " has no matching value",
+		},
+		{
+			description: "unknown type",
+			types:       []string{"Stage"},
+			protoTypes:  []string{"github.com/drshriveer/gtools/genum/internal/pb.Missing"},
+			expectedErr: "proto type github.com/drshriveer/gtools/genum/internal/pb.Missing not found",
+		},
+		{
+			description: "unqualified type",
+			types:       []string{"Stage"},
+			protoTypes:  []string{"Stage"},
+			expectedErr: "proto type Stage must be fully-qualified",
+		},
+		{
+			description: "one proto type per type",
+			types:       []string{"Stage", "Phase"},
+			protoTypes:  []string{protoType},
+			expectedErr: "expected one proto type for each of 2 types, found 1",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+			generator := gen.Generate{InFile: "./proto_enum.go", Types: test.types, ProtoTypes: test.protoTypes}
+			assert.ErrorContains(t, generator.Parse(), test.expectedErr)
+		})
+	}
+}
+
+func TestStage_Proto(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		stage internal.Stage
+		proto pb.Stage
+	}{
+		{stage: internal.StageUnspecified, proto: pb.Stage_STAGE_UNSPECIFIED},
+		{stage: internal.StagePending, proto: pb.Stage_STAGE_PENDING},
+		{stage: internal.StageRunning, proto: pb.Stage_STAGE_RUNNING},
+		{stage: internal.StageDone, proto: pb.Stage_STAGE_DONE},
+	}
+	for _, test := range tests {
+		t.Run(test.stage.String(), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.proto, test.stage.ToProto())
+			stage, err := internal.Stage(0).FromProto(test.proto)
+			require.NoError(t, err)
+			assert.Equal(t, test.stage, stage)
+		})
+	}
+
+	assert.Equal(t, pb.Stage_STAGE_DONE, internal.StageFinished.ToProto())
+	stage, err := internal.Stage(0).FromProto(pb.Stage_STAGE_FINISHED)
+	require.NoError(t, err)
+	assert.Equal(t, internal.StageDone, stage)
+
+	assert.Equal(t, pb.Stage_STAGE_UNSPECIFIED, internal.Stage(42).ToProto())
+	_, err = internal.Stage(0).FromProto(pb.Stage(42))
+	assert.ErrorContains(t, err, "`42` could not be converted from pb.Stage to enum of type Stage")
+}
@@ -0,0 +1,76 @@
+package internal_test
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/genum"
+	"github.com/drshriveer/gtools/genum/internal"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+	d, ok := genum.Lookup("internal.Color")
+	require.True(t, ok)
+	assert.Equal(t, "github.com/drshriveer/gtools/genum/internal.Color", d.FullName())
+	assert.Equal(t, reflect.TypeFor[internal.Color](), d.Type)
+	assert.Equal(t, []string{"blue", "green", "red"}, d.Names())
+	assert.Equal(t, []string{"Hex", "IsWarm"}, d.Traits)
+	assert.Equal(t, genum.ValueDescriptor{
+		Value:  internal.Red,
+		Name:   "red",
+		Traits: map[string]any{"Hex": "#ff0000", "IsWarm": true},
+	}, d.Values[2])
+
+	parsed, err := d.Parse("#00ff00")
+	require.NoError(t, err)
+	assert.Equal(t, internal.Green, parsed)
+
+	full, ok := genum.Lookup("github.com/drshriveer/gtools/genum/internal.Color")
+	require.True(t, ok)
+	assert.Same(t, d, full)
+	byType, ok := genum.DefaultRegistry.LookupType(reflect.TypeFor[internal.Color]())
+	require.True(t, ok)
+	assert.Same(t, d, byType)
+
+	_, ok = genum.Lookup("internal.Colour")
+	assert.False(t, ok)
+
+	severity, ok := genum.Lookup("internal.Severity")
+	require.True(t, ok)
+	assert.Equal(t, []string{"SeverityDebug", "SeverityInfo", "SeverityFatal", "SeverityWarning", "SeverityError"},
+		severity.Names(), "values are in the enum's order")
+	assert.Empty(t, severity.Traits)
+
+	all := genum.All()
+	assert.True(t, slices.IsSortedFunc(all, func(a, b *genum.Descriptor) int {
+		return strings.Compare(a.FullName(), b.FullName())
+	}))
+	assert.Contains(t, all, d)
+	assert.Contains(t, all, severity)
+}
+
+func TestRegistry_Ambiguous(t *testing.T) {
+	t.Parallel()
+	r := &genum.Registry{}
+	d := genum.Describe([]internal.Color{internal.Blue}, nil)
+	r.Register(d)
+	// a stand-in for an enum of the same name in another package.
+	r.Register(&genum.Descriptor{
+		Name:    "internal.Color",
+		PkgPath: "example.com/other/internal",
+		Type:    reflect.TypeFor[internal.Shape](),
+	})
+
+	_, ok := r.Lookup("internal.Color")
+	assert.False(t, ok, "ambiguous names are not found")
+	found, ok := r.Lookup("github.com/drshriveer/gtools/genum/internal.Color")
+	require.True(t, ok)
+	assert.Same(t, d, found)
+	assert.Len(t, r.All(), 2)
+}
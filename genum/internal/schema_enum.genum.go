@@ -0,0 +1,404 @@
+// Code generated by genum DO NOT EDIT.
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/drshriveer/gtools/genum"
+	"gopkg.in/yaml.v3"
+)
+
+var _CurrencyValues = []Currency{
+	CurrencyUSD,
+	CurrencyEUR,
+	CurrencyJPY,
+	CurrencyDEM,
+}
+
+func init() {
+	genum.Register(_CurrencyValues, map[string]func(Currency) any{
+		"MinorUnits": func(e Currency) any { return e.MinorUnits() },
+		"Rate":       func(e Currency) any { return e.Rate() },
+		"Symbol":     func(e Currency) any { return e.Symbol() },
+	})
+}
+
+// MinorUnits returns the enum's associated trait of the same name.
+// If no trait exists for the enumeration a default value will be returned.
+func (e Currency) MinorUnits() int {
+	switch e {
+	case CurrencyUSD:
+		return _MinorUnits
+	case CurrencyEUR:
+		return 2
+	case CurrencyJPY:
+		return 0
+	case CurrencyDEM:
+		return 2
+	}
+
+	return *new(int)
+}
+
+// Rate returns the enum's associated trait of the same name.
+// If no trait exists for the enumeration a default value will be returned.
+func (e Currency) Rate() float64 {
+	switch e {
+	case CurrencyUSD:
+		return _Rate
+	case CurrencyEUR:
+		return 1.08
+	case CurrencyJPY:
+		return 0.0067
+	case CurrencyDEM:
+		return 0.55
+	}
+
+	return *new(float64)
+}
+
+// Symbol returns the enum's associated trait of the same name.
+// If no trait exists for the enumeration a default value will be returned.
+func (e Currency) Symbol() string {
+	switch e {
+	case CurrencyUSD:
+		return _Symbol
+	case CurrencyEUR:
+		return "€"
+	case CurrencyJPY:
+		return "¥"
+	case CurrencyDEM:
+		return "DM"
+	}
+
+	return *new(string)
+}
+
+// IsValid returns true if the enum value is, in fact, valid.
+func (e Currency) IsValid() bool {
+	for _, v := range _CurrencyValues {
+		if v == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Values returns a list of all potential values of this enum, in value order.
+func (Currency) Values() []Currency {
+	return slices.Clone(_CurrencyValues)
+}
+
+// StringValues returns a list of all potential values of this enum as strings.
+// Note: This does not return duplicates.
+func (Currency) StringValues() []string {
+	return []string{
+		"CurrencyUSD",
+		"CurrencyEUR",
+		"CurrencyJPY",
+		"CurrencyDEM",
+	}
+}
+
+// String returns a string representation of this enum.
+// Note: in the case of duplicate values only the first alphabetical definition will be choosen.
+func (e Currency) String() string {
+	switch e {
+	case CurrencyUSD:
+		return "CurrencyUSD"
+	case CurrencyEUR:
+		return "CurrencyEUR"
+	case CurrencyJPY:
+		return "CurrencyJPY"
+	case CurrencyDEM:
+		return "CurrencyDEM"
+	default:
+		return fmt.Sprintf("UndefinedCurrency:%d", e)
+	}
+}
+
+// ParseString will return a value as defined in string form.
+func (e Currency) ParseString(text string) (Currency, error) {
+	return ParseCurrency(text)
+}
+
+// ParseCurrency will attempt to parse the value of a Currency from either its string form
+// or any value of a trait flagged with the --parsableByTrait flag.
+func ParseCurrency(input any) (Currency, error) {
+	switch input {
+	case "CurrencyUSD":
+		return CurrencyUSD, nil
+	case "CurrencyEUR":
+		return CurrencyEUR, nil
+	case "CurrencyJPY":
+		return CurrencyJPY, nil
+	case "CurrencyDEM":
+		return CurrencyDEM, nil
+	default:
+		return 0, fmt.Errorf("`%+v` could not be parsed to enum of type Currency", input)
+	}
+}
+
+// ParseGeneric calls TypedEnum.Parse but returns the result
+// in the generic genum.Enum interface. Which is useful when you are only able to work with
+// the un-typed interface.
+func (e Currency) ParseGeneric(input any) (genum.Enum, error) {
+	return ParseCurrency(input)
+}
+
+// MarshalJSON implements the json.Marshaler interface for Currency.
+func (e Currency) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Currency.
+func (e *Currency) UnmarshalJSON(data []byte) error {
+	// We always support strings.
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		var err error
+		*e, err = ParseCurrency(s)
+		if err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unable to unmarshal Currency from `%v`", data)
+}
+
+// JSONSchema returns the JSON Schema of Currency, which is compatible with OpenAPI 3.
+func (Currency) JSONSchema() []byte {
+	return []byte(`{
+	"title": "Currency",
+	"description": "Currency is an ISO 4217 currency.\n\nAmounts are stored in minor units.",
+	"type": "string",
+	"enum": [
+		"CurrencyUSD",
+		"CurrencyEUR",
+		"CurrencyJPY",
+		"CurrencyDEM"
+	],
+	"x-enum-varnames": [
+		"CurrencyUSD",
+		"CurrencyEUR",
+		"CurrencyJPY",
+		"CurrencyDEM"
+	],
+	"x-enum-descriptions": [
+		"CurrencyUSD is the United States dollar.",
+		"CurrencyEUR is the euro.",
+		"Japanese yen.",
+		"CurrencyDEM is the Deutsche Mark."
+	],
+	"x-enum-deprecated": [
+		"CurrencyDEM"
+	],
+	"x-traits": {
+		"MinorUnits": [
+			2,
+			2,
+			0,
+			2
+		],
+		"Rate": [
+			1,
+			1.08,
+			0.0067,
+			0.55
+		],
+		"Symbol": [
+			"$",
+			"€",
+			"¥",
+			"DM"
+		]
+	}
+}`)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for Currency.
+func (e Currency) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for Currency.
+func (e *Currency) UnmarshalText(text []byte) error {
+	s := string(text)
+	var err error
+	*e, err = ParseCurrency(s)
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("unable to unmarshal Currency from `%s`", s)
+}
+
+// MarshalYAML implements a YAML Marshaler for Currency.
+func (e Currency) MarshalYAML() (any, error) {
+	return e.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for Currency.
+func (e *Currency) UnmarshalYAML(value *yaml.Node) error {
+	var err error
+
+	// first try and parse as a string
+	*e, err = ParseCurrency(value.Value)
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("unable to unmarshal Currency from yaml `%s`", value.Value)
+}
+
+// IsEnum implements an empty function required to implement Enum.
+func (Currency) IsEnum() {}
+
+var _LegacyCurrencyValues = []LegacyCurrency{
+	LegacyFRF,
+	LegacyITL,
+}
+
+func init() {
+	genum.Register(_LegacyCurrencyValues, nil)
+}
+
+// IsValid returns true if the enum value is, in fact, valid.
+func (e LegacyCurrency) IsValid() bool {
+	for _, v := range _LegacyCurrencyValues {
+		if v == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Values returns a list of all potential values of this enum, in value order.
+func (LegacyCurrency) Values() []LegacyCurrency {
+	return slices.Clone(_LegacyCurrencyValues)
+}
+
+// StringValues returns a list of all potential values of this enum as strings.
+// Note: This does not return duplicates.
+func (LegacyCurrency) StringValues() []string {
+	return []string{
+		"FRF",
+		"ITL",
+	}
+}
+
+// String returns a string representation of this enum.
+// Note: in the case of duplicate values only the first alphabetical definition will be choosen.
+func (e LegacyCurrency) String() string {
+	switch e {
+	case LegacyFRF:
+		return "FRF"
+	case LegacyITL:
+		return "ITL"
+	default:
+		return fmt.Sprintf("UndefinedLegacyCurrency:%d", e)
+	}
+}
+
+// ParseString will return a value as defined in string form.
+func (e LegacyCurrency) ParseString(text string) (LegacyCurrency, error) {
+	return ParseLegacyCurrency(text)
+}
+
+// ParseLegacyCurrency will attempt to parse the value of a LegacyCurrency from either its string form
+// or any value of a trait flagged with the --parsableByTrait flag.
+func ParseLegacyCurrency(input any) (LegacyCurrency, error) {
+	switch input {
+	case "FRF", "LegacyFRF":
+		return LegacyFRF, nil
+	case "ITL", "LegacyITL":
+		return LegacyITL, nil
+	default:
+		return 0, fmt.Errorf("`%+v` could not be parsed to enum of type LegacyCurrency", input)
+	}
+}
+
+// ParseGeneric calls TypedEnum.Parse but returns the result
+// in the generic genum.Enum interface. Which is useful when you are only able to work with
+// the un-typed interface.
+func (e LegacyCurrency) ParseGeneric(input any) (genum.Enum, error) {
+	return ParseLegacyCurrency(input)
+}
+
+// MarshalJSON implements the json.Marshaler interface for LegacyCurrency.
+func (e LegacyCurrency) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for LegacyCurrency.
+func (e *LegacyCurrency) UnmarshalJSON(data []byte) error {
+	// We always support strings.
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		var err error
+		*e, err = ParseLegacyCurrency(s)
+		if err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unable to unmarshal LegacyCurrency from `%v`", data)
+}
+
+// JSONSchema returns the JSON Schema of LegacyCurrency, which is compatible with OpenAPI 3.
+func (LegacyCurrency) JSONSchema() []byte {
+	return []byte(`{
+	"title": "LegacyCurrency",
+	"description": "LegacyCurrency holds currencies that are no longer issued.",
+	"deprecated": true,
+	"type": "string",
+	"enum": [
+		"FRF",
+		"ITL"
+	],
+	"x-enum-varnames": [
+		"LegacyFRF",
+		"LegacyITL"
+	]
+}`)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for LegacyCurrency.
+func (e LegacyCurrency) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for LegacyCurrency.
+func (e *LegacyCurrency) UnmarshalText(text []byte) error {
+	s := string(text)
+	var err error
+	*e, err = ParseLegacyCurrency(s)
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("unable to unmarshal LegacyCurrency from `%s`", s)
+}
+
+// MarshalYAML implements a YAML Marshaler for LegacyCurrency.
+func (e LegacyCurrency) MarshalYAML() (any, error) {
+	return e.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for LegacyCurrency.
+func (e *LegacyCurrency) UnmarshalYAML(value *yaml.Node) error {
+	var err error
+
+	// first try and parse as a string
+	*e, err = ParseLegacyCurrency(value.Value)
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("unable to unmarshal LegacyCurrency from yaml `%s`", value.Value)
+}
+
+// IsEnum implements an empty function required to implement Enum.
+func (LegacyCurrency) IsEnum() {}
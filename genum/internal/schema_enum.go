@@ -0,0 +1,31 @@
+//nolint:revive // test only
+package internal
+
+//go:generate genum -types=Currency,LegacyCurrency -schemaOut=schema_enum.json
+
+// Currency is an ISO 4217 currency.
+//
+// Amounts are stored in minor units.
+type Currency int
+
+const (
+	// CurrencyUSD is the United States dollar.
+	CurrencyUSD, _MinorUnits, _Symbol, _Rate = Currency(iota), 2, "$", float64(1)
+	// CurrencyEUR is the euro.
+	CurrencyEUR, _, _, _ = Currency(iota), 2, "€", 1.08
+	CurrencyJPY, _, _, _ = Currency(iota), 0, "¥", 0.0067 // Japanese yen.
+
+	// CurrencyDEM is the Deutsche Mark.
+	// Deprecated: replaced by CurrencyEUR.
+	CurrencyDEM, _, _, _ = Currency(iota), 2, "DM", 0.55
+)
+
+// LegacyCurrency holds currencies that are no longer issued.
+//
+// Deprecated: use Currency.
+type LegacyCurrency int
+
+const (
+	LegacyFRF LegacyCurrency = iota // genum:"FRF"
+	LegacyITL                       // genum:"ITL"
+)
@@ -0,0 +1,108 @@
+package internal_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/genum/gen"
+	"github.com/drshriveer/gtools/genum/internal"
+)
+
+func TestSchemaGeneration(t *testing.T) {
+	t.Parallel()
+	generator := gen.Generate{
+		InFile:       "./schema_enum.go",
+		Types:        []string{"Currency"},
+		SchemaFormat: "swagger",
+	}
+	assert.ErrorContains(t, generator.Parse(), "unknown schema format swagger")
+
+	generator = gen.Generate{
+		InFile:    "./schema_enum.go",
+		Types:     []string{"Currency"},
+		SchemaOut: "schema_enum.json",
+		GenJSON:   false,
+	}
+	assert.ErrorContains(t, generator.Parse(), "schemaOut requires json marshal methods")
+
+	dir := t.TempDir()
+	generator = gen.Generate{
+		InFile:       "./schema_enum.go",
+		OutFile:      filepath.Join(dir, "schema_enum.genum.go"),
+		Types:        []string{"Currency", "LegacyCurrency"},
+		GenJSON:      true,
+		SchemaOut:    filepath.Join(dir, "openapi.json"),
+		SchemaFormat: "openapi",
+	}
+	require.NoError(t, generator.Parse())
+	require.NoError(t, generator.Write())
+
+	data, err := os.ReadFile(generator.SchemaOut)
+	require.NoError(t, err)
+	var doc struct {
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.Contains(t, doc.Components.Schemas, "LegacyCurrency")
+	assert.JSONEq(t, string(internal.CurrencyUSD.JSONSchema()), string(doc.Components.Schemas["Currency"]))
+}
+
+func TestJSONSchema(t *testing.T) {
+	t.Parallel()
+	assert.JSONEq(t, `{
+		"title": "Currency",
+		"description": "Currency is an ISO 4217 currency.\n\nAmounts are stored in minor units.",
+		"type": "string",
+		"enum": ["CurrencyUSD", "CurrencyEUR", "CurrencyJPY", "CurrencyDEM"],
+		"x-enum-varnames": ["CurrencyUSD", "CurrencyEUR", "CurrencyJPY", "CurrencyDEM"],
+		"x-enum-descriptions": [
+			"CurrencyUSD is the United States dollar.",
+			"CurrencyEUR is the euro.",
+			"Japanese yen.",
+			"CurrencyDEM is the Deutsche Mark."
+		],
+		"x-enum-deprecated": ["CurrencyDEM"],
+		"x-traits": {
+			"MinorUnits": [2, 2, 0, 2],
+			"Rate": [1, 1.08, 0.0067, 0.55],
+			"Symbol": ["$", "€", "¥", "DM"]
+		}
+	}`, string(internal.CurrencyUSD.JSONSchema()))
+
+	assert.JSONEq(t, `{
+		"title": "LegacyCurrency",
+		"description": "LegacyCurrency holds currencies that are no longer issued.",
+		"deprecated": true,
+		"type": "string",
+		"enum": ["FRF", "ITL"],
+		"x-enum-varnames": ["LegacyFRF", "LegacyITL"]
+	}`, string(internal.LegacyFRF.JSONSchema()))
+
+	assert.JSONEq(t, `{
+		"title": "Permission",
+		"description": "Permission is a bit-flag enum.",
+		"type": "array",
+		"uniqueItems": true,
+		"items": {
+			"type": "string",
+			"enum": ["NoPermission", "Read", "Write", "Execute"],
+			"x-enum-varnames": ["NoPermission", "Read", "Write", "Execute"]
+		}
+	}`, string(internal.Read.JSONSchema()))
+
+	// the checked in schema file matches the generated method.
+	data, err := os.ReadFile("schema_enum.json")
+	require.NoError(t, err)
+	var doc struct {
+		Defs map[string]json.RawMessage `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.JSONEq(t, string(internal.CurrencyUSD.JSONSchema()), string(doc.Defs["Currency"]))
+}
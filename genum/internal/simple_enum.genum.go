@@ -2,8 +2,10 @@
 package internal
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"slices"
 
 	"github.com/drshriveer/gtools/genum"
@@ -18,6 +20,10 @@ var _MyEnumValues = []MyEnum{
 	Enum1Value7,
 }
 
+func init() {
+	genum.Register(_MyEnumValues, nil)
+}
+
 // IsValid returns true if the enum value is, in fact, valid.
 func (e MyEnum) IsValid() bool {
 	for _, v := range _MyEnumValues {
@@ -28,11 +34,55 @@ func (e MyEnum) IsValid() bool {
 	return false
 }
 
-// Values returns a list of all potential values of this enum.
+// Values returns a list of all potential values of this enum, in value order.
 func (MyEnum) Values() []MyEnum {
 	return slices.Clone(_MyEnumValues)
 }
 
+// Min returns the first value of MyEnum in value order.
+func (MyEnum) Min() MyEnum {
+	return Enum1IntentionallyNegative
+}
+
+// Max returns the last value of MyEnum in value order.
+func (MyEnum) Max() MyEnum {
+	return Enum1Value7
+}
+
+// Ordinal returns the position of the enum in Values, or -1 if it is invalid.
+func (e MyEnum) Ordinal() int {
+	return slices.Index(_MyEnumValues, e)
+}
+
+// Next returns the value following this one in Values.
+// False is returned if this is the last value or the enum is invalid.
+func (e MyEnum) Next() (MyEnum, bool) {
+	if i := e.Ordinal(); i >= 0 && i+1 < len(_MyEnumValues) {
+		return _MyEnumValues[i+1], true
+	}
+	return 0, false
+}
+
+// Prev returns the value preceding this one in Values.
+// False is returned if this is the first value or the enum is invalid.
+func (e MyEnum) Prev() (MyEnum, bool) {
+	if i := e.Ordinal(); i > 0 {
+		return _MyEnumValues[i-1], true
+	}
+	return 0, false
+}
+
+// Compare returns -1, 0, or +1 depending on whether the enum comes before, is the same as, or comes after
+// other in Values. Invalid values come before all valid values.
+func (e MyEnum) Compare(other MyEnum) int {
+	return cmp.Compare(e.Ordinal(), other.Ordinal())
+}
+
+// All returns an iterator over all potential values of this enum, in value order.
+func (MyEnum) All() iter.Seq[MyEnum] {
+	return slices.Values(_MyEnumValues)
+}
+
 // StringValues returns a list of all potential values of this enum as strings.
 // Note: This does not return duplicates.
 func (MyEnum) StringValues() []string {
@@ -121,6 +171,36 @@ func (e *MyEnum) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("unable to unmarshal MyEnum from `%v`", data)
 }
 
+// JSONSchema returns the JSON Schema of MyEnum, which is compatible with OpenAPI 3.
+func (MyEnum) JSONSchema() []byte {
+	return []byte(`{
+	"title": "MyEnum",
+	"description": "MyEnum is a mess of a definition;\n- multiple constants resolve to the same value\n- definitions are spread across multiple blocks.",
+	"type": "string",
+	"enum": [
+		"Enum1IntentionallyNegative",
+		"Enum1Value0",
+		"Enum1Value1",
+		"Enum1Value2",
+		"Enum1Value7"
+	],
+	"x-enum-varnames": [
+		"Enum1IntentionallyNegative",
+		"Enum1Value0",
+		"Enum1Value1",
+		"Enum1Value2",
+		"Enum1Value7"
+	],
+	"x-enum-descriptions": [
+		"",
+		"Enum1Value0 is the default value and is completely unset.",
+		"",
+		"",
+		"Enum1Value7 is a special thing."
+	]
+}`)
+}
+
 // MarshalText implements the encoding.TextMarshaler interface for MyEnum.
 func (e MyEnum) MarshalText() ([]byte, error) {
 	return []byte(e.String()), nil
@@ -164,6 +244,10 @@ var _MyEnum2Values = []MyEnum2{
 	Enum2Value1,
 }
 
+func init() {
+	genum.Register(_MyEnum2Values, nil)
+}
+
 // IsValid returns true if the enum value is, in fact, valid.
 func (e MyEnum2) IsValid() bool {
 	for _, v := range _MyEnum2Values {
@@ -174,11 +258,55 @@ func (e MyEnum2) IsValid() bool {
 	return false
 }
 
-// Values returns a list of all potential values of this enum.
+// Values returns a list of all potential values of this enum, in value order.
 func (MyEnum2) Values() []MyEnum2 {
 	return slices.Clone(_MyEnum2Values)
 }
 
+// Min returns the first value of MyEnum2 in value order.
+func (MyEnum2) Min() MyEnum2 {
+	return Enum2Value0
+}
+
+// Max returns the last value of MyEnum2 in value order.
+func (MyEnum2) Max() MyEnum2 {
+	return Enum2Value1
+}
+
+// Ordinal returns the position of the enum in Values, or -1 if it is invalid.
+func (e MyEnum2) Ordinal() int {
+	return slices.Index(_MyEnum2Values, e)
+}
+
+// Next returns the value following this one in Values.
+// False is returned if this is the last value or the enum is invalid.
+func (e MyEnum2) Next() (MyEnum2, bool) {
+	if i := e.Ordinal(); i >= 0 && i+1 < len(_MyEnum2Values) {
+		return _MyEnum2Values[i+1], true
+	}
+	return 0, false
+}
+
+// Prev returns the value preceding this one in Values.
+// False is returned if this is the first value or the enum is invalid.
+func (e MyEnum2) Prev() (MyEnum2, bool) {
+	if i := e.Ordinal(); i > 0 {
+		return _MyEnum2Values[i-1], true
+	}
+	return 0, false
+}
+
+// Compare returns -1, 0, or +1 depending on whether the enum comes before, is the same as, or comes after
+// other in Values. Invalid values come before all valid values.
+func (e MyEnum2) Compare(other MyEnum2) int {
+	return cmp.Compare(e.Ordinal(), other.Ordinal())
+}
+
+// All returns an iterator over all potential values of this enum, in value order.
+func (MyEnum2) All() iter.Seq[MyEnum2] {
+	return slices.Values(_MyEnum2Values)
+}
+
 // StringValues returns a list of all potential values of this enum as strings.
 // Note: This does not return duplicates.
 func (MyEnum2) StringValues() []string {
@@ -246,6 +374,23 @@ func (e *MyEnum2) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("unable to unmarshal MyEnum2 from `%v`", data)
 }
 
+// JSONSchema returns the JSON Schema of MyEnum2, which is compatible with OpenAPI 3.
+func (MyEnum2) JSONSchema() []byte {
+	return []byte(`{
+	"title": "MyEnum2",
+	"description": "MyEnum2 is simple, but still a little messy as it is defined in the middle\nof MyEnum.",
+	"type": "string",
+	"enum": [
+		"Enum2Value0",
+		"Enum2Value1"
+	],
+	"x-enum-varnames": [
+		"Enum2Value0",
+		"Enum2Value1"
+	]
+}`)
+}
+
 // MarshalText implements the encoding.TextMarshaler interface for MyEnum2.
 func (e MyEnum2) MarshalText() ([]byte, error) {
 	return []byte(e.String()), nil
@@ -303,17 +448,68 @@ var _MyEnum3Values = []MyEnum3{
 	Enum3Value16,
 }
 
+func init() {
+	genum.Register(_MyEnum3Values, nil)
+}
+
 // IsValid returns true if the enum value is, in fact, valid.
 func (e MyEnum3) IsValid() bool {
 	_, ok := slices.BinarySearch(_MyEnum3Values, e)
 	return ok
 }
 
-// Values returns a list of all potential values of this enum.
+// Values returns a list of all potential values of this enum, in value order.
 func (MyEnum3) Values() []MyEnum3 {
 	return slices.Clone(_MyEnum3Values)
 }
 
+// Min returns the first value of MyEnum3 in value order.
+func (MyEnum3) Min() MyEnum3 {
+	return Enum3Value0
+}
+
+// Max returns the last value of MyEnum3 in value order.
+func (MyEnum3) Max() MyEnum3 {
+	return Enum3Value16
+}
+
+// Ordinal returns the position of the enum in Values, or -1 if it is invalid.
+func (e MyEnum3) Ordinal() int {
+	if i, ok := slices.BinarySearch(_MyEnum3Values, e); ok {
+		return i
+	}
+	return -1
+}
+
+// Next returns the value following this one in Values.
+// False is returned if this is the last value or the enum is invalid.
+func (e MyEnum3) Next() (MyEnum3, bool) {
+	if i := e.Ordinal(); i >= 0 && i+1 < len(_MyEnum3Values) {
+		return _MyEnum3Values[i+1], true
+	}
+	return 0, false
+}
+
+// Prev returns the value preceding this one in Values.
+// False is returned if this is the first value or the enum is invalid.
+func (e MyEnum3) Prev() (MyEnum3, bool) {
+	if i := e.Ordinal(); i > 0 {
+		return _MyEnum3Values[i-1], true
+	}
+	return 0, false
+}
+
+// Compare returns -1, 0, or +1 depending on whether the enum comes before, is the same as, or comes after
+// other in Values. Invalid values come before all valid values.
+func (e MyEnum3) Compare(other MyEnum3) int {
+	return cmp.Compare(e.Ordinal(), other.Ordinal())
+}
+
+// All returns an iterator over all potential values of this enum, in value order.
+func (MyEnum3) All() iter.Seq[MyEnum3] {
+	return slices.Values(_MyEnum3Values)
+}
+
 // StringValues returns a list of all potential values of this enum as strings.
 // Note: This does not return duplicates.
 func (MyEnum3) StringValues() []string {
@@ -451,6 +647,51 @@ func (e *MyEnum3) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("unable to unmarshal MyEnum3 from `%v`", data)
 }
 
+// JSONSchema returns the JSON Schema of MyEnum3, which is compatible with OpenAPI 3.
+func (MyEnum3) JSONSchema() []byte {
+	return []byte(`{
+	"title": "MyEnum3",
+	"description": "MyEnum3 is a simple, well-formed enum with nothing special.",
+	"type": "string",
+	"enum": [
+		"Enum3Value0",
+		"Enum3Value1",
+		"Enum3Value2",
+		"Enum3Value3",
+		"Enum3Value4",
+		"Enum3Value5",
+		"Enum3Value6",
+		"Enum3Value7",
+		"Enum3Value8",
+		"Enum3Value9",
+		"Enum3Value10",
+		"Enum3Value11",
+		"Enum3Value12",
+		"Enum3Value13",
+		"Enum3Value15",
+		"Enum3Value16"
+	],
+	"x-enum-varnames": [
+		"Enum3Value0",
+		"Enum3Value1",
+		"Enum3Value2",
+		"Enum3Value3",
+		"Enum3Value4",
+		"Enum3Value5",
+		"Enum3Value6",
+		"Enum3Value7",
+		"Enum3Value8",
+		"Enum3Value9",
+		"Enum3Value10",
+		"Enum3Value11",
+		"Enum3Value12",
+		"Enum3Value13",
+		"Enum3Value15",
+		"Enum3Value16"
+	]
+}`)
+}
+
 // MarshalText implements the encoding.TextMarshaler interface for MyEnum3.
 func (e MyEnum3) MarshalText() ([]byte, error) {
 	return []byte(e.String()), nil
@@ -1,7 +1,7 @@
 //nolint:revive // test only
 package internal
 
-//go:generate genum -types=MyEnum,MyEnum2,MyEnum3
+//go:generate genum -types=MyEnum,MyEnum2,MyEnum3 -ordering
 
 // MyEnum is a mess of a definition;
 // - multiple constants resolve to the same value
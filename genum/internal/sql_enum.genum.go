@@ -0,0 +1,199 @@
+// Code generated by genum DO NOT EDIT.
+package internal
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/drshriveer/gtools/genum"
+	"gopkg.in/yaml.v3"
+)
+
+var _PriorityValues = []Priority{
+	PriorityLow,
+	PriorityMedium,
+	PriorityHigh,
+}
+
+func init() {
+	genum.Register(_PriorityValues, nil)
+}
+
+// IsValid returns true if the enum value is, in fact, valid.
+func (e Priority) IsValid() bool {
+	for _, v := range _PriorityValues {
+		if v == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Values returns a list of all potential values of this enum, in value order.
+func (Priority) Values() []Priority {
+	return slices.Clone(_PriorityValues)
+}
+
+// StringValues returns a list of all potential values of this enum as strings.
+// Note: This does not return duplicates.
+func (Priority) StringValues() []string {
+	return []string{
+		"PriorityLow",
+		"PriorityMedium",
+		"PriorityHigh",
+	}
+}
+
+// String returns a string representation of this enum.
+// Note: in the case of duplicate values only the first alphabetical definition will be choosen.
+func (e Priority) String() string {
+	switch e {
+	case PriorityLow:
+		return "PriorityLow"
+	case PriorityMedium:
+		return "PriorityMedium"
+	case PriorityHigh:
+		return "PriorityHigh"
+	default:
+		return fmt.Sprintf("UndefinedPriority:%d", e)
+	}
+}
+
+// ParseString will return a value as defined in string form.
+func (e Priority) ParseString(text string) (Priority, error) {
+	return ParsePriority(text)
+}
+
+// ParsePriority will attempt to parse the value of a Priority from either its string form
+// or any value of a trait flagged with the --parsableByTrait flag.
+func ParsePriority(input any) (Priority, error) {
+	switch input {
+	case "PriorityLow":
+		return PriorityLow, nil
+	case "PriorityMedium":
+		return PriorityMedium, nil
+	case "PriorityHigh":
+		return PriorityHigh, nil
+	default:
+		return 0, fmt.Errorf("`%+v` could not be parsed to enum of type Priority", input)
+	}
+}
+
+// ParseGeneric calls TypedEnum.Parse but returns the result
+// in the generic genum.Enum interface. Which is useful when you are only able to work with
+// the un-typed interface.
+func (e Priority) ParseGeneric(input any) (genum.Enum, error) {
+	return ParsePriority(input)
+}
+
+// MarshalJSON implements the json.Marshaler interface for Priority.
+func (e Priority) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Priority.
+func (e *Priority) UnmarshalJSON(data []byte) error {
+	// We always support strings.
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		var err error
+		*e, err = ParsePriority(s)
+		if err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unable to unmarshal Priority from `%v`", data)
+}
+
+// JSONSchema returns the JSON Schema of Priority, which is compatible with OpenAPI 3.
+func (Priority) JSONSchema() []byte {
+	return []byte(`{
+	"title": "Priority",
+	"description": "Priority is stored in sql as an integer.",
+	"type": "string",
+	"enum": [
+		"PriorityLow",
+		"PriorityMedium",
+		"PriorityHigh"
+	],
+	"x-enum-varnames": [
+		"PriorityLow",
+		"PriorityMedium",
+		"PriorityHigh"
+	]
+}`)
+}
+
+// Value implements the driver.Valuer interface for Priority.
+// Values are stored as integers.
+func (e Priority) Value() (driver.Value, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("cannot store invalid Priority `%s`", e)
+	}
+	return int64(e), nil
+}
+
+// Scan implements the sql.Scanner interface for Priority.
+// Values may be scanned from their string form or their integer value.
+// NULL cannot be scanned; use sql.Null[Priority] for nullable columns.
+func (e *Priority) Scan(src any) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		err = fmt.Errorf("cannot scan NULL into Priority; use sql.Null[Priority] for nullable columns")
+	case string:
+		*e, err = ParsePriority(v)
+	case []byte:
+		*e, err = ParsePriority(string(v))
+	case int64:
+		if parsed := Priority(v); parsed.IsValid() {
+			*e = parsed
+			return nil
+		}
+		err = fmt.Errorf("`%d` is not a valid Priority", v)
+	default:
+		err = fmt.Errorf("unable to scan Priority from `%v` (%T)", src, src)
+	}
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for Priority.
+func (e Priority) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for Priority.
+func (e *Priority) UnmarshalText(text []byte) error {
+	s := string(text)
+	var err error
+	*e, err = ParsePriority(s)
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("unable to unmarshal Priority from `%s`", s)
+}
+
+// MarshalYAML implements a YAML Marshaler for Priority.
+func (e Priority) MarshalYAML() (any, error) {
+	return e.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for Priority.
+func (e *Priority) UnmarshalYAML(value *yaml.Node) error {
+	var err error
+
+	// first try and parse as a string
+	*e, err = ParsePriority(value.Value)
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("unable to unmarshal Priority from yaml `%s`", value.Value)
+}
+
+// IsEnum implements an empty function required to implement Enum.
+func (Priority) IsEnum() {}
@@ -0,0 +1,32 @@
+//nolint:revive // test only
+package internal
+
+//go:generate genum -types=Priority -sql
+//go:generate genum -types=Level -sql -sqlAsString -out=sql_enum_names.genum.go
+
+// Priority is stored in sql as an integer.
+type Priority int
+
+const (
+	PriorityLow Priority = iota
+	PriorityMedium
+	PriorityHigh
+)
+
+// Level is stored in sql as its string form.
+type Level int
+
+const (
+	Debug Level = iota
+	Info
+	Warn
+	Error
+)
+
+// Huge has a value that does not fit in an int64, so it may only be stored in sql as a string.
+type Huge uint64
+
+const (
+	HugeZero Huge = 0
+	HugeMax  Huge = 1<<64 - 1
+)
@@ -0,0 +1,207 @@
+// Code generated by genum DO NOT EDIT.
+package internal
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/drshriveer/gtools/genum"
+	"gopkg.in/yaml.v3"
+)
+
+var _LevelValues = []Level{
+	Debug,
+	Info,
+	Warn,
+	Error,
+}
+
+func init() {
+	genum.Register(_LevelValues, nil)
+}
+
+// IsValid returns true if the enum value is, in fact, valid.
+func (e Level) IsValid() bool {
+	for _, v := range _LevelValues {
+		if v == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Values returns a list of all potential values of this enum, in value order.
+func (Level) Values() []Level {
+	return slices.Clone(_LevelValues)
+}
+
+// StringValues returns a list of all potential values of this enum as strings.
+// Note: This does not return duplicates.
+func (Level) StringValues() []string {
+	return []string{
+		"Debug",
+		"Info",
+		"Warn",
+		"Error",
+	}
+}
+
+// String returns a string representation of this enum.
+// Note: in the case of duplicate values only the first alphabetical definition will be choosen.
+func (e Level) String() string {
+	switch e {
+	case Debug:
+		return "Debug"
+	case Info:
+		return "Info"
+	case Warn:
+		return "Warn"
+	case Error:
+		return "Error"
+	default:
+		return fmt.Sprintf("UndefinedLevel:%d", e)
+	}
+}
+
+// ParseString will return a value as defined in string form.
+func (e Level) ParseString(text string) (Level, error) {
+	return ParseLevel(text)
+}
+
+// ParseLevel will attempt to parse the value of a Level from either its string form
+// or any value of a trait flagged with the --parsableByTrait flag.
+func ParseLevel(input any) (Level, error) {
+	switch input {
+	case "Debug":
+		return Debug, nil
+	case "Info":
+		return Info, nil
+	case "Warn":
+		return Warn, nil
+	case "Error":
+		return Error, nil
+	default:
+		return 0, fmt.Errorf("`%+v` could not be parsed to enum of type Level", input)
+	}
+}
+
+// ParseGeneric calls TypedEnum.Parse but returns the result
+// in the generic genum.Enum interface. Which is useful when you are only able to work with
+// the un-typed interface.
+func (e Level) ParseGeneric(input any) (genum.Enum, error) {
+	return ParseLevel(input)
+}
+
+// MarshalJSON implements the json.Marshaler interface for Level.
+func (e Level) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Level.
+func (e *Level) UnmarshalJSON(data []byte) error {
+	// We always support strings.
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		var err error
+		*e, err = ParseLevel(s)
+		if err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unable to unmarshal Level from `%v`", data)
+}
+
+// JSONSchema returns the JSON Schema of Level, which is compatible with OpenAPI 3.
+func (Level) JSONSchema() []byte {
+	return []byte(`{
+	"title": "Level",
+	"description": "Level is stored in sql as its string form.",
+	"type": "string",
+	"enum": [
+		"Debug",
+		"Info",
+		"Warn",
+		"Error"
+	],
+	"x-enum-varnames": [
+		"Debug",
+		"Info",
+		"Warn",
+		"Error"
+	]
+}`)
+}
+
+// Value implements the driver.Valuer interface for Level.
+// Values are stored in their string form.
+func (e Level) Value() (driver.Value, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("cannot store invalid Level `%s`", e)
+	}
+	return e.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for Level.
+// Values may be scanned from their string form or their integer value.
+// NULL cannot be scanned; use sql.Null[Level] for nullable columns.
+func (e *Level) Scan(src any) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		err = fmt.Errorf("cannot scan NULL into Level; use sql.Null[Level] for nullable columns")
+	case string:
+		*e, err = ParseLevel(v)
+	case []byte:
+		*e, err = ParseLevel(string(v))
+	case int64:
+		if parsed := Level(v); parsed.IsValid() {
+			*e = parsed
+			return nil
+		}
+		err = fmt.Errorf("`%d` is not a valid Level", v)
+	default:
+		err = fmt.Errorf("unable to scan Level from `%v` (%T)", src, src)
+	}
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for Level.
+func (e Level) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for Level.
+func (e *Level) UnmarshalText(text []byte) error {
+	s := string(text)
+	var err error
+	*e, err = ParseLevel(s)
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("unable to unmarshal Level from `%s`", s)
+}
+
+// MarshalYAML implements a YAML Marshaler for Level.
+func (e Level) MarshalYAML() (any, error) {
+	return e.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for Level.
+func (e *Level) UnmarshalYAML(value *yaml.Node) error {
+	var err error
+
+	// first try and parse as a string
+	*e, err = ParseLevel(value.Value)
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("unable to unmarshal Level from yaml `%s`", value.Value)
+}
+
+// IsEnum implements an empty function required to implement Enum.
+func (Level) IsEnum() {}
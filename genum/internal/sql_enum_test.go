@@ -0,0 +1,171 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/genum/gen"
+	"github.com/drshriveer/gtools/genum/internal"
+)
+
+func TestSQLGeneration(t *testing.T) {
+	t.Parallel()
+	generator := gen.Generate{
+		InFile: "./sql_enum.go",
+		Types:  []string{"Huge"},
+		GenSQL: true,
+	}
+	assert.ErrorContains(t, generator.Parse(),
+		"Enum: Huge. value: HugeMax (18446744073709551615) does not fit in an int64")
+
+	generator.SQLAsString = true
+	assert.NoError(t, generator.Parse())
+}
+
+// memoryDB is a minimal in-memory database/sql driver: every `Exec` appends its arguments
+// to a single column and every `Query` returns all stored values.
+type memoryDB struct {
+	mu     sync.Mutex
+	values []driver.Value
+}
+
+func (d *memoryDB) Connect(context.Context) (driver.Conn, error) { return d, nil }
+func (d *memoryDB) Driver() driver.Driver                        { return nil }
+func (d *memoryDB) Prepare(string) (driver.Stmt, error)          { return d, nil }
+func (d *memoryDB) Close() error                                 { return nil }
+func (d *memoryDB) NumInput() int                                { return -1 }
+func (d *memoryDB) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+func (d *memoryDB) Exec(args []driver.Value) (driver.Result, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.values = append(d.values, args...)
+	return driver.RowsAffected(len(args)), nil
+}
+
+func (d *memoryDB) Query([]driver.Value) (driver.Rows, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return &memoryRows{values: slices.Clone(d.values)}, nil
+}
+
+type memoryRows struct {
+	values []driver.Value
+}
+
+func (r *memoryRows) Columns() []string { return []string{"value"} }
+func (r *memoryRows) Close() error      { return nil }
+func (r *memoryRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0], r.values = r.values[0], r.values[1:]
+	return nil
+}
+
+func sqlRoundTrip[T any](t *testing.T, values ...any) ([]driver.Value, []T) {
+	t.Helper()
+	mem := &memoryDB{}
+	db := sql.OpenDB(mem)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+
+	for _, v := range values {
+		_, err := db.Exec("INSERT", v)
+		require.NoError(t, err)
+	}
+	rows, err := db.Query("SELECT")
+	require.NoError(t, err)
+	defer rows.Close()
+	result := make([]T, 0, len(values))
+	for rows.Next() {
+		var v T
+		require.NoError(t, rows.Scan(&v))
+		result = append(result, v)
+	}
+	require.NoError(t, rows.Err())
+	return mem.values, result
+}
+
+func TestSQL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stored as integers", func(t *testing.T) {
+		t.Parallel()
+		stored, scanned := sqlRoundTrip[internal.Priority](t, internal.PriorityLow, internal.PriorityHigh)
+		assert.Equal(t, []driver.Value{int64(0), int64(2)}, stored)
+		assert.Equal(t, []internal.Priority{internal.PriorityLow, internal.PriorityHigh}, scanned)
+	})
+
+	t.Run("stored as names", func(t *testing.T) {
+		t.Parallel()
+		stored, scanned := sqlRoundTrip[internal.Level](t, internal.Info, internal.Error)
+		assert.Equal(t, []driver.Value{"Info", "Error"}, stored)
+		assert.Equal(t, []internal.Level{internal.Info, internal.Error}, scanned)
+	})
+
+	t.Run("string-backed enums are stored as strings", func(t *testing.T) {
+		t.Parallel()
+		stored, scanned := sqlRoundTrip[internal.Color](t, internal.Red, internal.Blue)
+		assert.Equal(t, []driver.Value{"red", "blue"}, stored)
+		assert.Equal(t, []internal.Color{internal.Red, internal.Blue}, scanned)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		t.Parallel()
+		_, scanned := sqlRoundTrip[sql.Null[internal.Priority]](t, nil, internal.PriorityMedium)
+		assert.Equal(t, []sql.Null[internal.Priority]{
+			{},
+			{V: internal.PriorityMedium, Valid: true},
+		}, scanned)
+	})
+
+	t.Run("invalid values are not stored", func(t *testing.T) {
+		t.Parallel()
+		db := sql.OpenDB(&memoryDB{})
+		t.Cleanup(func() { require.NoError(t, db.Close()) })
+		_, err := db.Exec("INSERT", internal.Priority(7))
+		assert.ErrorContains(t, err, "cannot store invalid Priority `UndefinedPriority:7`")
+	})
+}
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		description string
+		src         any
+		expected    internal.Priority
+		expectedErr string
+	}{
+		{description: "int64", src: int64(1), expected: internal.PriorityMedium},
+		{description: "string", src: "PriorityHigh", expected: internal.PriorityHigh},
+		{description: "bytes", src: []byte("PriorityLow"), expected: internal.PriorityLow},
+		{description: "invalid int64", src: int64(9), expectedErr: "`9` is not a valid Priority"},
+		{description: "invalid string", src: "Urgent", expectedErr: "`Urgent` could not be parsed"},
+		{description: "null", src: nil, expectedErr: "cannot scan NULL into Priority; use sql.Null[Priority]"},
+		{description: "unsupported type", src: 1.5, expectedErr: "unable to scan Priority from `1.5` (float64)"},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+			var p internal.Priority
+			err := p.Scan(test.src)
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, p)
+		})
+	}
+}
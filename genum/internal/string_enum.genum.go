@@ -0,0 +1,419 @@
+// Code generated by genum DO NOT EDIT.
+package internal
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/drshriveer/gtools/genum"
+	"gopkg.in/yaml.v3"
+)
+
+var _ColorValues = []Color{
+	Blue,
+	Green,
+	Red,
+}
+
+func init() {
+	genum.Register(_ColorValues, map[string]func(Color) any{
+		"Hex":    func(e Color) any { return e.Hex() },
+		"IsWarm": func(e Color) any { return e.IsWarm() },
+	})
+}
+
+// Hex returns the enum's associated trait of the same name.
+// If no trait exists for the enumeration a default value will be returned.
+func (e Color) Hex() string {
+	switch e {
+	case Blue:
+		return _Hex
+	case Green:
+		return "#00ff00"
+	case Red:
+		return "#ff0000"
+	}
+
+	return *new(string)
+}
+
+// IsWarm returns the enum's associated trait of the same name.
+// If no trait exists for the enumeration a default value will be returned.
+func (e Color) IsWarm() bool {
+	switch e {
+	case Blue:
+		return _IsWarm
+	case Green:
+		return false
+	case Red:
+		return true
+	}
+
+	return *new(bool)
+}
+
+// IsValid returns true if the enum value is, in fact, valid.
+func (e Color) IsValid() bool {
+	for _, v := range _ColorValues {
+		if v == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Values returns a list of all potential values of this enum, in value order.
+func (Color) Values() []Color {
+	return slices.Clone(_ColorValues)
+}
+
+// StringValues returns a list of all potential values of this enum as strings.
+// Note: This does not return duplicates.
+func (Color) StringValues() []string {
+	return []string{
+		"blue",
+		"green",
+		"red",
+	}
+}
+
+// String returns a string representation of this enum.
+// Note: in the case of duplicate values only the first alphabetical definition will be choosen.
+func (e Color) String() string {
+	switch e {
+	case Blue:
+		return "blue"
+	case Green:
+		return "green"
+	case Red:
+		return "red"
+	default:
+		return fmt.Sprintf("UndefinedColor:%s", string(e))
+	}
+}
+
+// ParseString will return a value as defined in string form.
+func (e Color) ParseString(text string) (Color, error) {
+	return ParseColor(text)
+}
+
+// ParseColor will attempt to parse the value of a Color from either its string form
+// or any value of a trait flagged with the --parsableByTrait flag.
+func ParseColor(input any) (Color, error) {
+	switch input {
+	case "blue", _Hex:
+		return Blue, nil
+	case "green", "#00ff00":
+		return Green, nil
+	case "red", "#ff0000":
+		return Red, nil
+	default:
+		return "", fmt.Errorf("`%+v` could not be parsed to enum of type Color", input)
+	}
+}
+
+// ParseGeneric calls TypedEnum.Parse but returns the result
+// in the generic genum.Enum interface. Which is useful when you are only able to work with
+// the un-typed interface.
+func (e Color) ParseGeneric(input any) (genum.Enum, error) {
+	return ParseColor(input)
+}
+
+// MarshalJSON implements the json.Marshaler interface for Color.
+func (e Color) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Color.
+func (e *Color) UnmarshalJSON(data []byte) error {
+	// We always support strings.
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		var err error
+		*e, err = ParseColor(s)
+		if err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unable to unmarshal Color from `%v`", data)
+}
+
+// JSONSchema returns the JSON Schema of Color, which is compatible with OpenAPI 3.
+func (Color) JSONSchema() []byte {
+	return []byte(`{
+	"title": "Color",
+	"description": "Color is a string-backed enum with traits; trait names are defined by the lowest\n(alphabetically first) value.",
+	"type": "string",
+	"enum": [
+		"blue",
+		"green",
+		"red"
+	],
+	"x-enum-varnames": [
+		"Blue",
+		"Green",
+		"Red"
+	],
+	"x-traits": {
+		"Hex": [
+			"#0000ff",
+			"#00ff00",
+			"#ff0000"
+		],
+		"IsWarm": [
+			false,
+			false,
+			true
+		]
+	}
+}`)
+}
+
+// Value implements the driver.Valuer interface for Color.
+// Values are stored in their string form.
+func (e Color) Value() (driver.Value, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("cannot store invalid Color `%s`", e)
+	}
+	return e.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for Color.
+// Values may be scanned from their string form.
+// NULL cannot be scanned; use sql.Null[Color] for nullable columns.
+func (e *Color) Scan(src any) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		err = fmt.Errorf("cannot scan NULL into Color; use sql.Null[Color] for nullable columns")
+	case string:
+		*e, err = ParseColor(v)
+	case []byte:
+		*e, err = ParseColor(string(v))
+	default:
+		err = fmt.Errorf("unable to scan Color from `%v` (%T)", src, src)
+	}
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for Color.
+func (e Color) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for Color.
+func (e *Color) UnmarshalText(text []byte) error {
+	s := string(text)
+	var err error
+	*e, err = ParseColor(s)
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("unable to unmarshal Color from `%s`", s)
+}
+
+// MarshalYAML implements a YAML Marshaler for Color.
+func (e Color) MarshalYAML() (any, error) {
+	return e.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for Color.
+func (e *Color) UnmarshalYAML(value *yaml.Node) error {
+	var err error
+
+	// first try and parse as a string
+	*e, err = ParseColor(value.Value)
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("unable to unmarshal Color from yaml `%s`", value.Value)
+}
+
+// IsEnum implements an empty function required to implement Enum.
+func (Color) IsEnum() {}
+
+var _ShapeValues = []Shape{
+	Circle,
+	Square,
+	Triangle,
+}
+
+func init() {
+	genum.Register(_ShapeValues, nil)
+}
+
+// IsValid returns true if the enum value is, in fact, valid.
+func (e Shape) IsValid() bool {
+	for _, v := range _ShapeValues {
+		if v == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Values returns a list of all potential values of this enum, in value order.
+func (Shape) Values() []Shape {
+	return slices.Clone(_ShapeValues)
+}
+
+// StringValues returns a list of all potential values of this enum as strings.
+// Note: This does not return duplicates.
+func (Shape) StringValues() []string {
+	return []string{
+		"circle",
+		"square",
+		"triangle",
+	}
+}
+
+// String returns a string representation of this enum.
+// Note: in the case of duplicate values only the first alphabetical definition will be choosen.
+func (e Shape) String() string {
+	switch e {
+	case Circle:
+		return "circle"
+	case Square:
+		return "square"
+	case Triangle:
+		return "triangle"
+	default:
+		return fmt.Sprintf("UndefinedShape:%s", string(e))
+	}
+}
+
+// ParseString will return a value as defined in string form.
+func (e Shape) ParseString(text string) (Shape, error) {
+	return ParseShape(text)
+}
+
+// ParseShape will attempt to parse the value of a Shape from either its string form
+// or any value of a trait flagged with the --parsableByTrait flag.
+func ParseShape(input any) (Shape, error) {
+	switch input {
+	case "circle":
+		return Circle, nil
+	case "square":
+		return Square, nil
+	case "triangle":
+		return Triangle, nil
+	default:
+		return "", fmt.Errorf("`%+v` could not be parsed to enum of type Shape", input)
+	}
+}
+
+// ParseGeneric calls TypedEnum.Parse but returns the result
+// in the generic genum.Enum interface. Which is useful when you are only able to work with
+// the un-typed interface.
+func (e Shape) ParseGeneric(input any) (genum.Enum, error) {
+	return ParseShape(input)
+}
+
+// MarshalJSON implements the json.Marshaler interface for Shape.
+func (e Shape) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Shape.
+func (e *Shape) UnmarshalJSON(data []byte) error {
+	// We always support strings.
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		var err error
+		*e, err = ParseShape(s)
+		if err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unable to unmarshal Shape from `%v`", data)
+}
+
+// JSONSchema returns the JSON Schema of Shape, which is compatible with OpenAPI 3.
+func (Shape) JSONSchema() []byte {
+	return []byte(`{
+	"title": "Shape",
+	"description": "Shape is a string-backed enum with a duplicate value.",
+	"type": "string",
+	"enum": [
+		"circle",
+		"square",
+		"triangle"
+	],
+	"x-enum-varnames": [
+		"Circle",
+		"Square",
+		"Triangle"
+	]
+}`)
+}
+
+// Value implements the driver.Valuer interface for Shape.
+// Values are stored in their string form.
+func (e Shape) Value() (driver.Value, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("cannot store invalid Shape `%s`", e)
+	}
+	return e.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for Shape.
+// Values may be scanned from their string form.
+// NULL cannot be scanned; use sql.Null[Shape] for nullable columns.
+func (e *Shape) Scan(src any) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		err = fmt.Errorf("cannot scan NULL into Shape; use sql.Null[Shape] for nullable columns")
+	case string:
+		*e, err = ParseShape(v)
+	case []byte:
+		*e, err = ParseShape(string(v))
+	default:
+		err = fmt.Errorf("unable to scan Shape from `%v` (%T)", src, src)
+	}
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for Shape.
+func (e Shape) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for Shape.
+func (e *Shape) UnmarshalText(text []byte) error {
+	s := string(text)
+	var err error
+	*e, err = ParseShape(s)
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("unable to unmarshal Shape from `%s`", s)
+}
+
+// MarshalYAML implements a YAML Marshaler for Shape.
+func (e Shape) MarshalYAML() (any, error) {
+	return e.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for Shape.
+func (e *Shape) UnmarshalYAML(value *yaml.Node) error {
+	var err error
+
+	// first try and parse as a string
+	*e, err = ParseShape(value.Value)
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("unable to unmarshal Shape from yaml `%s`", value.Value)
+}
+
+// IsEnum implements an empty function required to implement Enum.
+func (Shape) IsEnum() {}
@@ -0,0 +1,26 @@
+//nolint:revive // test only
+package internal
+
+//go:generate genum -types=Color,Shape -parsableByTraits=Hex -sql
+
+// Color is a string-backed enum with traits; trait names are defined by the lowest
+// (alphabetically first) value.
+type Color string
+
+const (
+	Blue, _Hex, _IsWarm = Color("blue"), "#0000ff", false
+	Green, _, _         = Color("green"), "#00ff00", false
+	Red, _, _           = Color("red"), "#ff0000", true
+)
+
+// Shape is a string-backed enum with a duplicate value.
+type Shape string
+
+const (
+	Circle   Shape = "circle"
+	Square   Shape = "square"
+	Triangle Shape = "triangle"
+
+	// Deprecated: use Square.
+	Box Shape = "square"
+)
@@ -0,0 +1,77 @@
+package internal_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/genum"
+	"github.com/drshriveer/gtools/genum/gen"
+	"github.com/drshriveer/gtools/genum/internal"
+)
+
+func TestStringEnumGeneration(t *testing.T) {
+	t.Parallel()
+	generator := gen.Generate{
+		InFile:           "./string_enum.go",
+		OutFile:          "./string_enum.genum.go",
+		Types:            []string{"Color", "Shape"},
+		ParsableByTraits: []string{"Hex"},
+		GenJSON:          true,
+		GenYAML:          true,
+		GenText:          true,
+	}
+	require.NoError(t, generator.Parse())
+
+	generator.GenFlags = true
+	assert.ErrorContains(t, generator.Parse(), "string-backed enums cannot be bit-flag enums")
+}
+
+func TestColor(t *testing.T) {
+	t.Parallel()
+	assert.Implements(t, (*genum.TypedEnum[internal.Color])(nil), internal.Red)
+	tests := []enumTest[internal.Color]{
+		{enum: internal.Blue, sName: "blue"},
+		{enum: internal.Green, sName: "green"},
+		{enum: internal.Red, sName: "red"},
+		{enum: internal.Color("purple"), invalid: true},
+		{enum: internal.Color(""), invalid: true},
+	}
+
+	testRunner[internal.Color](t, tests)
+}
+
+func TestColor_Traits(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "#ff0000", internal.Red.Hex())
+	assert.True(t, internal.Red.IsWarm())
+	assert.False(t, internal.Blue.IsWarm())
+	assert.Equal(t, "UndefinedColor:purple", internal.Color("purple").String())
+
+	parsed, err := internal.ParseColor("#00ff00")
+	require.NoError(t, err)
+	assert.Equal(t, internal.Green, parsed)
+
+	var fromJSON internal.Color
+	require.NoError(t, json.Unmarshal([]byte(`"#0000ff"`), &fromJSON))
+	assert.Equal(t, internal.Blue, fromJSON)
+
+	_, err = internal.ParseColor("Red")
+	assert.ErrorContains(t, err, "`Red` could not be parsed to enum of type Color",
+		"string-backed enums parse from their values, not their names")
+}
+
+func TestShape(t *testing.T) {
+	t.Parallel()
+	tests := []enumTest[internal.Shape]{
+		{enum: internal.Circle, sName: "circle"},
+		{enum: internal.Square, sName: "square"},
+		{enum: internal.Box, sName: "square", duplicateDefinition: true},
+		{enum: internal.Triangle, sName: "triangle"},
+		{enum: internal.Shape("hexagon"), invalid: true},
+	}
+
+	testRunner[internal.Shape](t, tests)
+}
@@ -0,0 +1,64 @@
+//nolint:revive // test only
+package internal
+
+//go:generate genum -types=JobState -transform=trimPrefix,kebab
+
+// JobState is the lifecycle of a job.
+//
+//genum:transitions JobStatePending->JobStateRunning,JobStateCanceled
+//genum:transitions JobStateRunning->JobStateSucceeded,JobStateFailed,JobStateCanceled
+//genum:transitions JobStateFailed->JobStatePending JobStateCancelled->JobStateCancelled
+type JobState int
+
+const (
+	JobStatePending JobState = iota
+	JobStateRunning
+	JobStateSucceeded
+	JobStateFailed
+	JobStateCanceled
+
+	// Deprecated: use JobStateCanceled.
+	JobStateCancelled = JobStateCanceled
+)
+
+// BadTransitions refers to a value that does not exist.
+//
+//genum:transitions BadA->BadB,BadC
+type BadTransitions int
+
+const (
+	BadA BadTransitions = iota
+	BadB
+)
+
+// MalformedTransitions has a transition without a destination.
+//
+//genum:transitions MalformedA->
+type MalformedTransitions int
+
+const (
+	MalformedA MalformedTransitions = iota
+	MalformedB
+)
+
+// Grouped types share this doc comment, so its directive belongs to neither of them.
+//
+//genum:transitions GroupedA->GroupedB
+type (
+	// GroupedTransitions is declared in a group.
+	//
+	//genum:transitions GroupedB->GroupedA
+	GroupedTransitions int
+	// UngroupedTransitions is declared in the same group but has no transitions.
+	UngroupedTransitions int
+)
+
+const (
+	GroupedA GroupedTransitions = iota
+	GroupedB
+)
+
+const (
+	UngroupedA UngroupedTransitions = iota
+	UngroupedB
+)
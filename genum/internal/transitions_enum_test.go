@@ -0,0 +1,115 @@
+package internal_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drshriveer/gtools/genum"
+	"github.com/drshriveer/gtools/genum/gen"
+	"github.com/drshriveer/gtools/genum/internal"
+)
+
+func TestTransitionsGeneration(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		enumType    string
+		expected    []string
+		expectedErr string
+	}{
+		{
+			enumType: "JobState",
+			expected: []string{
+				"JobStatePending->JobStateRunning,JobStateCanceled",
+				"JobStateRunning->JobStateSucceeded,JobStateFailed,JobStateCanceled",
+				"JobStateFailed->JobStatePending",
+				"JobStateCanceled->JobStateCanceled",
+			},
+		},
+		{
+			enumType: "GroupedTransitions",
+			expected: []string{"GroupedB->GroupedA"},
+		},
+		{
+			enumType: "UngroupedTransitions",
+			expected: []string{},
+		},
+		{
+			enumType:    "BadTransitions",
+			expectedErr: "Enum: BadTransitions. transition `BadA->BadB,BadC` refers to unknown value BadC.",
+		},
+		{
+			enumType:    "MalformedTransitions",
+			expectedErr: "Enum: MalformedTransitions. invalid transition `MalformedA->`",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.enumType, func(t *testing.T) {
+			t.Parallel()
+			generator := gen.Generate{
+				InFile: "./transitions_enum.go",
+				Types:  []string{test.enumType},
+			}
+			err := generator.Parse()
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			rules := make([]string, 0, len(generator.Transitions[0]))
+			for _, transition := range generator.Transitions[0] {
+				to := make([]string, len(transition.To))
+				for i, v := range transition.To {
+					to[i] = v.Name
+				}
+				rules = append(rules, transition.From.Name+"->"+strings.Join(to, ","))
+			}
+			assert.Equal(t, test.expected, rules)
+		})
+	}
+}
+
+func TestTransitions(t *testing.T) {
+	t.Parallel()
+	assert.True(t, internal.JobStatePending.CanTransitionTo(internal.JobStateRunning))
+	assert.True(t, internal.JobStateFailed.CanTransitionTo(internal.JobStatePending))
+	assert.True(t, internal.JobStateCancelled.CanTransitionTo(internal.JobStateCanceled), "duplicates are interchangeable")
+	assert.False(t, internal.JobStatePending.CanTransitionTo(internal.JobStateSucceeded))
+	assert.False(t, internal.JobStateSucceeded.CanTransitionTo(internal.JobStatePending))
+	assert.False(t, internal.JobState(10).CanTransitionTo(internal.JobStatePending))
+
+	assert.Equal(t,
+		[]internal.JobState{internal.JobStateSucceeded, internal.JobStateFailed, internal.JobStateCanceled},
+		internal.JobStateRunning.Transitions())
+	assert.Empty(t, internal.JobStateSucceeded.Transitions())
+
+	state, err := internal.JobStatePending.Transition(internal.JobStateRunning)
+	require.NoError(t, err)
+	assert.Equal(t, internal.JobStateRunning, state)
+
+	state, err = state.Transition(internal.JobStatePending)
+	assert.Equal(t, internal.JobStateRunning, state)
+	var transitionErr *genum.TransitionError
+	require.ErrorAs(t, err, &transitionErr)
+	assert.Equal(t, internal.JobStateRunning, transitionErr.From)
+	assert.Equal(t, internal.JobStatePending, transitionErr.To)
+	assert.EqualError(t, err, "internal.JobState cannot transition from running to pending")
+
+	assert.Equal(t, `digraph JobState {
+	"pending";
+	"running";
+	"succeeded";
+	"failed";
+	"canceled";
+	"pending" -> "running";
+	"pending" -> "canceled";
+	"running" -> "succeeded";
+	"running" -> "failed";
+	"running" -> "canceled";
+	"failed" -> "pending";
+	"canceled" -> "canceled";
+}
+`, internal.JobState(0).TransitionsDOT())
+}
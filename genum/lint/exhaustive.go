@@ -0,0 +1,284 @@
+// Package lint provides static analysis of code using genum enums.
+package lint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/drshriveer/gtools/genum/internal/astutil"
+)
+
+const genumPkgPath = "github.com/drshriveer/gtools/genum"
+
+const doc = `check that switch statements and map literals over genum enums are exhaustive
+
+Types implementing genum.Enum are recognized as enums, and every constant of an enum's
+type declared in a non-generated file of its package as one of its values. A switch
+statement on an enum must have a case for every value, and a map literal keyed by an enum
+must have a key for every value. A value is deprecated only if all of its constants are;
+deprecated values, and values only reachable through unexported constants from other
+packages, need not be covered. Bit-flag enums (those with Has and Toggle methods, as
+generated by genum -flags) are sets of values rather than single values and are not
+checked, nor are empty map literals and generated files.`
+
+// Analyzer reports switch statements and map literals over genum enums that do not cover every
+// value of the enum.
+var Analyzer = &analysis.Analyzer{
+	Name:      "genumexhaustive",
+	Doc:       doc,
+	URL:       "https://pkg.go.dev/github.com/drshriveer/gtools/genum/lint",
+	Run:       run,
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	FactTypes: []analysis.Fact{(*enumFact)(nil)},
+}
+
+var defaultSignifiesExhaustive bool
+
+func init() {
+	Analyzer.Flags.BoolVar(&defaultSignifiesExhaustive, "default-signifies-exhaustive", false,
+		"switch statements with a default case are always exhaustive")
+}
+
+// enumFact records the values of an enum type so they are known to the packages that use it.
+type enumFact struct {
+	Values []enumValue
+}
+
+// enumValue is a constant of an enum type.
+type enumValue struct {
+	Name       string
+	Value      string // the exact string of the constant's value.
+	Deprecated bool
+}
+
+// AFact implements analysis.Fact.
+func (*enumFact) AFact() {}
+
+func (f *enumFact) String() string {
+	names := make([]string, len(f.Values))
+	for i, v := range f.Values {
+		names[i] = v.Name
+	}
+	return "enum(" + strings.Join(names, ", ") + ")"
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	exportEnumFacts(pass)
+
+	generated := make(map[*token.File]bool, len(pass.Files))
+	for _, f := range pass.Files {
+		if ast.IsGenerated(f) {
+			generated[pass.Fset.File(f.Pos())] = true
+		}
+	}
+
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{(*ast.SwitchStmt)(nil), (*ast.CompositeLit)(nil)}
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		if generated[pass.Fset.File(n.Pos())] {
+			return
+		}
+		switch n := n.(type) {
+		case *ast.SwitchStmt:
+			checkSwitch(pass, n)
+		case *ast.CompositeLit:
+			checkMapLiteral(pass, n)
+		}
+	})
+	return nil, nil
+}
+
+// exportEnumFacts exports an enumFact for every enum type declared in the package.
+func exportEnumFacts(pass *analysis.Pass) {
+	enumIface := findEnumInterface(pass.Pkg)
+	if enumIface == nil {
+		return
+	}
+
+	scope := pass.Pkg.Scope()
+	for _, name := range scope.Names() {
+		typeName, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || typeName.IsAlias() || !types.Implements(typeName.Type(), enumIface) ||
+			isFlagsEnum(typeName) {
+			continue
+		}
+
+		fact := &enumFact{}
+		for _, constName := range scope.Names() {
+			c, ok := scope.Lookup(constName).(*types.Const)
+			if !ok || !types.Identical(c.Type(), typeName.Type()) {
+				continue
+			}
+			// constants declared by generated code are not values.
+			f := fileOf(pass, c.Pos())
+			if f == nil || ast.IsGenerated(f) {
+				continue
+			}
+			fact.Values = append(fact.Values, enumValue{
+				Name:       constName,
+				Value:      c.Val().ExactString(),
+				Deprecated: astutil.IsDeprecated(f, constName),
+			})
+		}
+		if len(fact.Values) > 0 {
+			pass.ExportObjectFact(typeName, fact)
+		}
+	}
+}
+
+// isFlagsEnum returns true if the enum is a set of bit flags, i.e. it has the Has and Toggle
+// methods genum generates with -flags.
+func isFlagsEnum(typeName *types.TypeName) bool {
+	t := typeName.Type()
+	return hasMethod(typeName, "Has", t, types.Typ[types.Bool]) && hasMethod(typeName, "Toggle", t, t)
+}
+
+// hasMethod returns true if the type has a method name with a single parameter and result of the
+// given types.
+func hasMethod(typeName *types.TypeName, name string, param, result types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(typeName.Type(), false, typeName.Pkg(), name)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig, ok := fn.Type().(*types.Signature)
+	return ok && sig.Params().Len() == 1 && sig.Results().Len() == 1 &&
+		types.Identical(sig.Params().At(0).Type(), param) && types.Identical(sig.Results().At(0).Type(), result)
+}
+
+// findEnumInterface returns genum.Enum if it is imported by the package.
+func findEnumInterface(pkg *types.Package) *types.Interface {
+	for _, imported := range pkg.Imports() {
+		if imported.Path() != genumPkgPath {
+			continue
+		}
+		if enum, ok := imported.Scope().Lookup("Enum").(*types.TypeName); ok {
+			iface, _ := enum.Type().Underlying().(*types.Interface)
+			return iface
+		}
+	}
+	return nil
+}
+
+// fileOf returns the file of the package containing pos.
+func fileOf(pass *analysis.Pass, pos token.Pos) *ast.File {
+	for _, f := range pass.Files {
+		if f.FileStart <= pos && pos < f.FileEnd {
+			return f
+		}
+	}
+	return nil
+}
+
+func checkSwitch(pass *analysis.Pass, stmt *ast.SwitchStmt) {
+	if stmt.Tag == nil {
+		return
+	}
+	typeName, fact := enumOf(pass, pass.TypesInfo.TypeOf(stmt.Tag))
+	if fact == nil {
+		return
+	}
+
+	covered := make(map[string]bool, len(fact.Values))
+	for _, clause := range stmt.Body.List {
+		cc, ok := clause.(*ast.CaseClause)
+		if !ok {
+			continue
+		}
+		if cc.List == nil && defaultSignifiesExhaustive {
+			return
+		}
+		for _, expr := range cc.List {
+			if tv, ok := pass.TypesInfo.Types[expr]; ok && tv.Value != nil {
+				covered[tv.Value.ExactString()] = true
+			}
+		}
+	}
+
+	if missing := missingValues(pass, typeName, fact, covered); len(missing) > 0 {
+		pass.Reportf(stmt.Pos(), "missing cases in switch of type %s: %s",
+			types.TypeString(typeName.Type(), types.RelativeTo(pass.Pkg)), strings.Join(missing, ", "))
+	}
+}
+
+func checkMapLiteral(pass *analysis.Pass, lit *ast.CompositeLit) {
+	if len(lit.Elts) == 0 {
+		return
+	}
+	mapType, ok := pass.TypesInfo.TypeOf(lit).Underlying().(*types.Map)
+	if !ok {
+		return
+	}
+	typeName, fact := enumOf(pass, mapType.Key())
+	if fact == nil {
+		return
+	}
+
+	covered := make(map[string]bool, len(fact.Values))
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if tv, ok := pass.TypesInfo.Types[kv.Key]; ok && tv.Value != nil {
+			covered[tv.Value.ExactString()] = true
+		}
+	}
+
+	if missing := missingValues(pass, typeName, fact, covered); len(missing) > 0 {
+		pass.Reportf(lit.Pos(), "missing keys in map of type %s: %s",
+			types.TypeString(mapType, types.RelativeTo(pass.Pkg)), strings.Join(missing, ", "))
+	}
+}
+
+// enumOf returns the enum type and its values if typ is an enum.
+func enumOf(pass *analysis.Pass, typ types.Type) (*types.TypeName, *enumFact) {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return nil, nil
+	}
+	fact := &enumFact{}
+	if !pass.ImportObjectFact(named.Obj(), fact) {
+		return nil, nil
+	}
+	return named.Obj(), fact
+}
+
+// missingValues returns the names of the values of an enum that are not covered.
+// A value is covered if any constant with the same value is. A value is deprecated only if all of
+// its constants are; deprecated values, and values not accessible from the package being analyzed,
+// are never missing. Missing values are named by a constant that is not deprecated if possible.
+func missingValues(pass *analysis.Pass, typeName *types.TypeName, fact *enumFact, covered map[string]bool) []string {
+	live := make(map[string]bool, len(fact.Values))
+	for _, v := range fact.Values {
+		live[v.Value] = live[v.Value] || !v.Deprecated
+	}
+
+	samePackage := typeName.Pkg() == pass.Pkg
+	order := make([]string, 0)
+	names := make(map[string]enumValue, len(fact.Values))
+	for _, v := range fact.Values {
+		if covered[v.Value] || !live[v.Value] || !(samePackage || token.IsExported(v.Name)) {
+			continue
+		}
+		name, ok := names[v.Value]
+		if !ok {
+			order = append(order, v.Value)
+		}
+		if !ok || (name.Deprecated && !v.Deprecated) {
+			names[v.Value] = v
+		}
+	}
+
+	missing := make([]string, len(order))
+	for i, value := range order {
+		missing[i] = names[value].Name
+	}
+	return missing
+}
@@ -0,0 +1,20 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/drshriveer/gtools/genum/lint"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), lint.Analyzer, "enums", "usage")
+}
+
+func TestAnalyzer_DefaultSignifiesExhaustive(t *testing.T) {
+	require.NoError(t, lint.Analyzer.Flags.Set("default-signifies-exhaustive", "true"))
+	t.Cleanup(func() { _ = lint.Analyzer.Flags.Set("default-signifies-exhaustive", "false") })
+	analysistest.Run(t, analysistest.TestData(), lint.Analyzer, "defaulted")
+}
@@ -0,0 +1,14 @@
+package defaulted
+
+import "enums"
+
+func remote(f enums.Fruit) {
+	switch f {
+	case enums.Apple:
+	default:
+	}
+
+	switch f { // want "missing cases in switch of type enums.Fruit: Banana, Cherry"
+	case enums.Apple:
+	}
+}
@@ -0,0 +1,104 @@
+package enums
+
+import "github.com/drshriveer/gtools/genum"
+
+type Fruit int // want Fruit:"enum\\(Apple, Banana, Cherry, Default, Durian, secret\\)"
+
+const (
+	Apple Fruit = iota
+	Banana
+	Cherry
+	// Durian is no longer sold.
+	// Deprecated: durian is not allowed on public transit.
+	Durian
+	secret
+)
+
+// Default is an alias of Apple.
+const Default = Apple
+
+type Vegetable int // want Vegetable:"enum\\(Carrot, Leek, Onion, Scallion\\)"
+
+const (
+	Carrot Vegetable = iota
+	// Deprecated: use Scallion.
+	Onion
+	Leek
+)
+
+// Scallion is the preferred name of Onion, so Onion's value is not deprecated.
+const Scallion = Onion
+
+// Permission is a set of bit flags, which are not checked.
+type Permission int
+
+const (
+	Read Permission = 1 << iota
+	Write
+	Execute
+)
+
+// NotAnEnum is not a genum.Enum.
+type NotAnEnum int
+
+const (
+	One NotAnEnum = iota
+	Two
+)
+
+func local(f Fruit) {
+	switch f { // want "missing cases in switch of type Fruit: Cherry, secret"
+	case Apple, Banana:
+	}
+
+	switch f {
+	case Default, Banana, Cherry, secret:
+	}
+
+	_ = map[Fruit]string{ // want "missing keys in map of type map\\[Fruit\\]string: Banana, Cherry"
+		Apple:  "apple",
+		secret: "secret",
+	}
+
+	_ = map[Fruit]string{}
+
+	switch NotAnEnum(f) {
+	case One:
+	}
+}
+
+func vegetables(v Vegetable, p Permission) {
+	switch v { // want "missing cases in switch of type Vegetable: Leek, Scallion"
+	case Carrot:
+	}
+
+	switch v {
+	case Carrot, Onion, Leek:
+	}
+
+	switch p {
+	case Read | Write:
+	}
+
+	_ = map[Permission]string{Read: "r"}
+}
+
+func (Fruit) IsValid() bool                              { return true }
+func (Fruit) StringValues() []string                     { return nil }
+func (Fruit) String() string                             { return "" }
+func (Fruit) IsEnum()                                    {}
+func (Fruit) ParseGeneric(input any) (genum.Enum, error) { return nil, nil }
+
+func (Vegetable) IsValid() bool                              { return true }
+func (Vegetable) StringValues() []string                     { return nil }
+func (Vegetable) String() string                             { return "" }
+func (Vegetable) IsEnum()                                    {}
+func (Vegetable) ParseGeneric(input any) (genum.Enum, error) { return nil, nil }
+
+func (Permission) IsValid() bool                              { return true }
+func (Permission) StringValues() []string                     { return nil }
+func (Permission) String() string                             { return "" }
+func (Permission) IsEnum()                                    {}
+func (Permission) ParseGeneric(input any) (genum.Enum, error) { return nil, nil }
+func (p Permission) Has(flags Permission) bool                { return p&flags == flags }
+func (p Permission) Toggle(flags Permission) Permission       { return p ^ flags }
@@ -0,0 +1,10 @@
+package genum
+
+// Enum is a stand-in for the real genum.Enum.
+type Enum interface {
+	IsValid() bool
+	StringValues() []string
+	String() string
+	IsEnum()
+	ParseGeneric(input any) (Enum, error)
+}
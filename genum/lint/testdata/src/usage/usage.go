@@ -0,0 +1,27 @@
+package usage
+
+import "enums"
+
+func remote(f enums.Fruit) {
+	switch f { // want "missing cases in switch of type enums.Fruit: Banana, Cherry"
+	case enums.Apple:
+	default:
+	}
+
+	switch f {
+	case enums.Apple, enums.Banana, enums.Cherry:
+	}
+
+	_ = map[enums.Fruit]bool{ // want "missing keys in map of type map\\[enums.Fruit\\]bool: Cherry"
+		enums.Default: true,
+		enums.Banana:  true,
+	}
+}
+
+func remoteVegetables(v enums.Vegetable, p enums.Permission) {
+	switch v { // want "missing cases in switch of type enums.Vegetable: Scallion"
+	case enums.Carrot, enums.Leek:
+	}
+
+	_ = map[enums.Permission]bool{enums.Read: true}
+}
@@ -0,0 +1,158 @@
+package genum
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Descriptor describes an enum type so that it can be used without knowing the type.
+// Descriptors are shared and must not be modified.
+type Descriptor struct {
+	// Name is the package-qualified name of the enum, e.g. `pkg.Stage`.
+	Name string
+	// PkgPath is the import path of the package the enum is declared in.
+	PkgPath string
+	// Type is the type of the enum.
+	Type reflect.Type
+	// Values are all potential values of the enum, in the order returned by Values().
+	Values []ValueDescriptor
+	// Traits are the names of the enum's traits, sorted.
+	Traits []string
+}
+
+// FullName returns the name of the enum qualified by its import path, e.g. `github.com/org/pkg.Stage`.
+func (d *Descriptor) FullName() string {
+	return d.PkgPath + "." + d.Type.Name()
+}
+
+// Names returns the string form of each value of the enum.
+func (d *Descriptor) Names() []string {
+	result := make([]string, len(d.Values))
+	for i, v := range d.Values {
+		result[i] = v.Name
+	}
+	return result
+}
+
+// Parse parses a value of the enum from any input its generated Parse function accepts.
+func (d *Descriptor) Parse(input any) (Enum, error) {
+	return reflect.Zero(d.Type).Interface().(Enum).ParseGeneric(input)
+}
+
+// ValueDescriptor describes a single value of an enum.
+type ValueDescriptor struct {
+	// Value is the value itself.
+	Value Enum
+	// Name is the string form of the value.
+	Name string
+	// Traits holds the value of each of the enum's traits for this value.
+	Traits map[string]any
+}
+
+// Describe returns a Descriptor of the enum T with the given values and trait accessors.
+func Describe[T Enum](values []T, traits map[string]func(T) any) *Descriptor {
+	t := reflect.TypeFor[T]()
+	d := &Descriptor{
+		Name:    t.String(),
+		PkgPath: t.PkgPath(),
+		Type:    t,
+		Values:  make([]ValueDescriptor, len(values)),
+		Traits:  make([]string, 0, len(traits)),
+	}
+	for name := range traits {
+		d.Traits = append(d.Traits, name)
+	}
+	slices.Sort(d.Traits)
+
+	for i, v := range values {
+		d.Values[i] = ValueDescriptor{
+			Value:  v,
+			Name:   v.String(),
+			Traits: make(map[string]any, len(traits)),
+		}
+		for name, trait := range traits {
+			d.Values[i].Traits[name] = trait(v)
+		}
+	}
+	return d
+}
+
+// Registry holds descriptors of enums, looked up by name.
+// The zero value is an empty registry ready to use.
+type Registry struct {
+	mu     sync.RWMutex
+	byType map[reflect.Type]*Descriptor
+}
+
+// Register adds an enum to the registry, replacing any previous descriptor of the same type.
+func (r *Registry) Register(d *Descriptor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.byType == nil {
+		r.byType = make(map[reflect.Type]*Descriptor)
+	}
+	r.byType[d.Type] = d
+}
+
+// Lookup returns the descriptor of an enum by its package-qualified name, e.g. `pkg.Stage`, or,
+// to distinguish between packages with the same name, its full name, e.g. `github.com/org/pkg.Stage`.
+// False is returned if the name is unknown or ambiguous.
+func (r *Registry) Lookup(name string) (*Descriptor, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var found *Descriptor
+	for _, d := range r.byType {
+		if d.FullName() == name {
+			return d, true
+		}
+		if d.Name == name {
+			if found != nil {
+				return nil, false
+			}
+			found = d
+		}
+	}
+	return found, found != nil
+}
+
+// LookupType returns the descriptor of an enum by its type.
+func (r *Registry) LookupType(t reflect.Type) (*Descriptor, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	d, ok := r.byType[t]
+	return d, ok
+}
+
+// All returns the descriptors of all registered enums, sorted by full name.
+func (r *Registry) All() []*Descriptor {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]*Descriptor, 0, len(r.byType))
+	for _, d := range r.byType {
+		result = append(result, d)
+	}
+	slices.SortFunc(result, func(a, b *Descriptor) int {
+		return strings.Compare(a.FullName(), b.FullName())
+	})
+	return result
+}
+
+// DefaultRegistry is the registry generated enums are registered in.
+var DefaultRegistry = &Registry{}
+
+// Register adds the enum T to the DefaultRegistry; it is called by generated code.
+func Register[T Enum](values []T, traits map[string]func(T) any) {
+	DefaultRegistry.Register(Describe(values, traits))
+}
+
+// Lookup returns the descriptor of an enum in the DefaultRegistry; see Registry.Lookup.
+func Lookup(name string) (*Descriptor, bool) {
+	return DefaultRegistry.Lookup(name)
+}
+
+// All returns the descriptors of all enums in the DefaultRegistry, sorted by full name.
+func All() []*Descriptor {
+	return DefaultRegistry.All()
+}
@@ -0,0 +1,15 @@
+package genum
+
+import "fmt"
+
+// TransitionError is returned by generated Transition methods when an enum may not transition
+// from one value to another.
+type TransitionError struct {
+	From Enum
+	To   Enum
+}
+
+// Error implements the error interface.
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%T cannot transition from %s to %s", e.From, e.From, e.To)
+}